@@ -17,20 +17,239 @@
 package main
 
 import (
-	"log"
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
 
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/review"
 	"github.com/Renegade-Master/spotify-playlist-converter/internal/spotify"
 	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
+	spotifyapi "github.com/zmb3/spotify/v2"
 )
 
+var logger = logging.For("main")
+
 func main() {
-	spotifyClient := spotify.NewSpotify()
-	youtubeClient := youtube.NewYouTube()
+	command := "convert"
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	configFile := flags.String("config", "", "Path to a JSON configuration file")
+	verbose := flags.Bool("verbose", false, "Log debug output, including request dumps")
+	quiet := flags.Bool("quiet", false, "Only log warnings and errors")
+	logJSON := flags.Bool("log-json", false, "Log as JSON instead of text")
+	reportFile := flags.String("report", "", "Write a run report to this file (.md, .html, .json or .txt)")
+
+	var merge mergeFlags
+	if command == "merge" {
+		merge.name = flags.String("name", "", "Name of the merged YouTube Playlist")
+		merge.pattern = flags.String("pattern", "", "Merge every Spotify Playlist whose name matches this pattern, such as \"Workout *\"")
+		merge.order = flags.String("order", string(spotify.MergeBySource), "Order of the merged Tracks: source, interleave, added-at or shuffle")
+		merge.seed = flags.Uint64("seed", 0, "Seed for the shuffle order")
+	}
+	var dedupe dedupeFlags
+	if command == "dedupe" {
+		dedupe.dryRun = flags.Bool("dry-run", false, "Only print the duplicates which would be removed")
+		dedupe.yes = flags.Bool("yes", false, "Remove the duplicates without asking for confirmation")
+	}
+	_ = flags.Parse(args)
+
+	logging.Setup(logging.Options{Verbose: *verbose, Quiet: *quiet, JSON: *logJSON}, os.Stderr)
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		logging.Fatal(logger, "Error loading configuration", "error", err)
+	}
+
+	switch command {
+	case "convert":
+		convert(cfg, *reportFile)
+	case "album":
+		convertAlbums(cfg, *reportFile, flags.Args())
+	case "merge":
+		mergePlaylists(cfg, *reportFile, merge, flags.Args())
+	case "dedupe":
+		dedupePlaylists(cfg, dedupe, flags.Args())
+	case "review":
+		reviewMatches(cfg)
+	default:
+		logging.Fatal(logger, "Unknown command. Expected one of [convert, album, merge, dedupe, review]", "command", command)
+	}
+}
+
+func convert(cfg *config.Config, reportFile string) {
+	spotifyClient := spotify.NewSpotify(cfg)
+	youtubeClient := youtube.NewYouTube(cfg)
 
 	spotifyPlaylistId := spotifyClient.GetPlaylists()[0].ID
 	spotifyClient.AddPlaylistToYouTube(spotifyPlaylistId, youtubeClient)
 
 	//spotifyClient.AddAllPlaylists(&youtubeClient)
 
-	log.Printf("Used [%d] YouTube Credits", youtubeClient.Credits)
+	finishRun(spotifyClient, youtubeClient, reportFile)
+}
+
+// convertAlbums converts each Spotify Album ID given on the command line
+func convertAlbums(cfg *config.Config, reportFile string, albumIds []string) {
+	if len(albumIds) == 0 {
+		logging.Fatal(logger, "No Spotify Album IDs given")
+	}
+
+	spotifyClient := spotify.NewSpotify(cfg)
+	youtubeClient := youtube.NewYouTube(cfg)
+
+	for _, albumId := range albumIds {
+		spotifyClient.AddAlbumToYouTube(spotifyapi.ID(albumId), youtubeClient)
+	}
+
+	finishRun(spotifyClient, youtubeClient, reportFile)
+}
+
+// mergeFlags are the options of the merge command
+type mergeFlags struct {
+	name    *string
+	pattern *string
+	order   *string
+	seed    *uint64
+}
+
+// mergePlaylists merges the Spotify Playlist IDs given on the command line,
+// and those matching the pattern, into one YouTube Playlist
+func mergePlaylists(cfg *config.Config, reportFile string, merge mergeFlags, playlistIds []string) {
+	order, err := spotify.ParseMergeOrder(*merge.order)
+	if err != nil {
+		logging.Fatal(logger, "Invalid merge order", "error", err)
+	}
+	if *merge.name == "" {
+		logging.Fatal(logger, "No name given for the merged Playlist. Pass -name.")
+	}
+
+	spotifyClient := spotify.NewSpotify(cfg)
+	youtubeClient := youtube.NewYouTube(cfg)
+
+	var ids []spotifyapi.ID
+	for _, playlistId := range playlistIds {
+		ids = append(ids, spotifyapi.ID(playlistId))
+	}
+	if *merge.pattern != "" {
+		playlists, err := spotifyClient.FindPlaylists(*merge.pattern)
+		if err != nil {
+			logging.Fatal(logger, "Invalid Playlist pattern", "pattern", *merge.pattern, "error", err)
+		}
+		for _, playlist := range playlists {
+			if !slices.Contains(ids, playlist.ID) {
+				ids = append(ids, playlist.ID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		logging.Fatal(logger, "No Spotify Playlists to merge. Pass Playlist IDs or -pattern.")
+	}
+
+	spotifyClient.MergePlaylistsToYouTube(ids, spotify.MergeOptions{Name: *merge.name, Order: order, Seed: *merge.seed}, youtubeClient)
+
+	finishRun(spotifyClient, youtubeClient, reportFile)
+}
+
+// dedupeFlags are the options of the dedupe command
+type dedupeFlags struct {
+	dryRun *bool
+	yes    *bool
+}
+
+// dedupePlaylists removes the repeated videos from each YouTube Playlist ID
+// given on the command line, after printing what will be removed and asking
+// for confirmation
+func dedupePlaylists(cfg *config.Config, dedupe dedupeFlags, playlistIds []string) {
+	if len(playlistIds) == 0 {
+		logging.Fatal(logger, "No YouTube Playlist IDs given")
+	}
+
+	youtubeClient := youtube.NewYouTube(cfg)
+	stdin := bufio.NewScanner(os.Stdin)
+
+	for _, playlistId := range playlistIds {
+		groups := youtubeClient.FindDuplicates(playlistId, cfg.DedupeThreshold)
+		if len(groups) == 0 {
+			logger.Info("No duplicates found", "playlistId", playlistId)
+			continue
+		}
+
+		fmt.Fprintf(os.Stdout, "\n%s\n", playlistId)
+		if err := youtube.WriteDuplicatePlan(os.Stdout, groups); err != nil {
+			logger.Error("Error printing duplicates", "error", err)
+		}
+		if *dedupe.dryRun {
+			continue
+		}
+		if !*dedupe.yes && !confirm(stdin, fmt.Sprintf("Remove these duplicates from %s?", playlistId)) {
+			logger.Info("Not removing duplicates", "playlistId", playlistId)
+			continue
+		}
+
+		removed, err := youtubeClient.RemoveDuplicates(groups)
+		if err != nil {
+			logging.Fatal(logger, "Error removing duplicates", "playlistId", playlistId, "removed", removed, "error", err)
+		}
+		logger.Info("Removed duplicates", "playlistId", playlistId, "removed", removed)
+	}
+
+	logger.Info("Used YouTube Credits", "credits", youtubeClient.Credits)
+}
+
+// confirm asks a yes or no question, taking anything but yes as no
+func confirm(stdin *bufio.Scanner, question string) bool {
+	fmt.Fprintf(os.Stdout, "%s [y/N]: ", question)
+	if !stdin.Scan() {
+		return false
+	}
+
+	answer := strings.ToLower(strings.TrimSpace(stdin.Text()))
+	return answer == "y" || answer == "yes"
+}
+
+// finishRun prints the run report, and writes it to reportFile if one is given
+func finishRun(spotifyClient *spotify.Spotify, youtubeClient *youtube.YouTube, reportFile string) {
+	logger.Info("Used YouTube Credits", "credits", youtubeClient.Credits)
+
+	runReport := spotifyClient.Report()
+	runReport.Finish(youtubeClient.Credits)
+
+	if err := runReport.WriteTable(os.Stdout); err != nil {
+		logger.Error("Error printing run report", "error", err)
+	}
+
+	if reportFile != "" {
+		if err := runReport.WriteFile(reportFile); err != nil {
+			logging.Fatal(logger, "Error writing run report", "error", err)
+		}
+		logger.Info("Wrote run report", "file", reportFile)
+	}
+}
+
+func reviewMatches(cfg *config.Config) {
+	queue, err := review.Load(cfg.ReviewQueueFile)
+	if err != nil {
+		logging.Fatal(logger, "Error loading review queue", "error", err)
+	}
+
+	if len(queue.Entries) == 0 {
+		logger.Info("No matches waiting for review")
+		return
+	}
+
+	youtubeClient := youtube.NewYouTube(cfg)
+	if err := review.Run(queue, spotify.ReviewAdder(cfg, youtubeClient), os.Stdin, os.Stdout); err != nil {
+		logging.Fatal(logger, "Error applying reviewed matches", "error", err)
+	}
+
+	logger.Info("Used YouTube Credits", "credits", youtubeClient.Credits)
 }
@@ -0,0 +1,370 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/rules"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/util"
+)
+
+// The accepted values of Config.AlbumMode
+const (
+	AlbumLink   = "link"
+	AlbumCopy   = "copy"
+	AlbumTracks = "tracks"
+)
+
+// MinDedupeThreshold is the lowest DedupeThreshold which turns on removing
+// different videos with matching titles
+const MinDedupeThreshold = 0.9
+
+// The accepted values of Config.SearchStrategy
+const (
+	StrategyInnerTube = "innertube-only"
+	StrategyDataAPI   = "api-only"
+	StrategyHybrid    = "hybrid"
+)
+
+// The accepted values of Config.InsertMethod
+const (
+	InsertSingle    = "single"
+	InsertBatch     = "batch"
+	InsertInnerTube = "innertube"
+)
+
+// The accepted values of Config.PlaylistPrivacy
+const (
+	PrivacyPrivate  = "private"
+	PrivacyUnlisted = "unlisted"
+	PrivacyPublic   = "public"
+)
+
+// Config holds the settings for a conversion run. Any field missing from the
+// configuration file keeps its default value.
+type Config struct {
+	// ISRCLookup searches YouTube Music for the ISRC of each Track before
+	// falling back to searching by artist and title
+	ISRCLookup bool `json:"isrcLookup"`
+	// MatchAlgorithm scores the Similarity of Spotify and YouTube titles
+	MatchAlgorithm util.Algorithm `json:"matchAlgorithm"`
+	// MatchThreshold is the minimum score for a video to be a Candidate
+	MatchThreshold float64 `json:"matchThreshold"`
+	// SearchStrategy chooses between searching InnerTube, which is free, the
+	// Data API, which costs Credits, or InnerTube with a Data API fallback.
+	// InnerTube alone is the default, so that no Credits are spent on searches
+	// unless asked for.
+	SearchStrategy string `json:"searchStrategy"`
+	// QuotaBudget is the most Credits a run may have used for a Data API
+	// search to be made
+	QuotaBudget int `json:"quotaBudget"`
+	// InsertMethod is how videos are added to Playlists: one Data API call
+	// each, Data API batch requests, or InnerTube requests which cost no
+	// Credits
+	InsertMethod string `json:"insertMethod"`
+	// SearchPages is the most pages of results read while searching for a
+	// Track, when the first page does not hold enough Candidates
+	SearchPages int `json:"searchPages"`
+	// DuplicateThreshold is the minimum score for a Track to be considered
+	// already present in the YouTube Playlist
+	DuplicateThreshold float64 `json:"duplicateThreshold"`
+	// DedupeThreshold is the minimum score for two different videos to be
+	// considered the same song by the dedupe command. Zero only removes the
+	// same video repeated. Otherwise it must be at least MinDedupeThreshold,
+	// as songs by the same artist often have similar titles.
+	DedupeThreshold float64 `json:"dedupeThreshold"`
+
+	// DurationToleranceSeconds is how far a video's length may differ from the
+	// Track before its score is reduced
+	DurationToleranceSeconds int `json:"durationToleranceSeconds"`
+	// DurationRejectSeconds is how far a video's length may differ from the
+	// Track before it is rejected. Zero disables the duration check.
+	DurationRejectSeconds int `json:"durationRejectSeconds"`
+
+	// ReviewThreshold is the minimum Similarity score for a match to be added
+	// without review. Matches scoring below it are sent to the review queue.
+	ReviewThreshold float64 `json:"reviewThreshold"`
+	// ReviewQueueFile is where low-confidence matches are stored for review
+	ReviewQueueFile string `json:"reviewQueueFile"`
+	// ReviewCandidates is how many Candidates are kept for each queued match
+	ReviewCandidates int `json:"reviewCandidates"`
+
+	// StateFile remembers what earlier runs did to each converted Playlist
+	StateFile string `json:"stateFile"`
+
+	// OverridesFile pins Tracks to videos, and bans videos and channels
+	OverridesFile string `json:"overridesFile"`
+
+	// AlbumMode is how an Album found on YouTube Music is converted. "link"
+	// reports the official album playlist, "copy" copies its videos into a
+	// new Playlist, and "tracks" searches for every Track on its own.
+	AlbumMode string `json:"albumMode"`
+	// AlbumVerifyThreshold is the minimum score for a song of a YouTube Music
+	// album to be accepted as the Track at the same position
+	AlbumVerifyThreshold float64 `json:"albumVerifyThreshold"`
+
+	// PlaylistTitle is the template for the title of each YouTube Playlist.
+	// It is given the Name, Description, Owner and URL of the Spotify Playlist
+	// or Album. It may not use the Date, as existing Playlists are found by
+	// their title.
+	PlaylistTitle string `json:"playlistTitle"`
+	// PlaylistDescription is the template for the description of each YouTube
+	// Playlist, given the same fields as PlaylistTitle and the Date it was
+	// converted
+	PlaylistDescription string `json:"playlistDescription"`
+	// PlaylistPrivacy is who can see new YouTube Playlists
+	PlaylistPrivacy string `json:"playlistPrivacy"`
+	// PlaylistLanguage is the default language of new YouTube Playlists, such
+	// as "en". Empty leaves it unset.
+	PlaylistLanguage string `json:"playlistLanguage"`
+	// PlaylistTags are the tags given to new YouTube Playlists
+	PlaylistTags []string `json:"playlistTags"`
+	// PlaylistItemLimit is the most videos put in one YouTube Playlist. Larger
+	// sources are split into parts named "Name (Part 2)" and so on.
+	PlaylistItemLimit int `json:"playlistItemLimit"`
+	// CopyCoverArt uploads the cover of each Spotify Playlist as the image of
+	// its YouTube Playlist
+	CopyCoverArt bool `json:"copyCoverArt"`
+	// UpdateMetadata rewrites the description of an existing YouTube Playlist
+	// when it no longer matches its Spotify Playlist
+	UpdateMetadata bool `json:"updateMetadata"`
+
+	// Rules filter and reorder the Tracks of each Spotify Playlist before any
+	// are searched for
+	Rules rules.Rules `json:"rules"`
+
+	// Transliterate romanizes Cyrillic, Greek and Japanese kana titles before
+	// they are compared, so that they match romanized uploads
+	Transliterate bool `json:"transliterate"`
+}
+
+// Default returns the Config used when no configuration file is given
+func Default() *Config {
+	return &Config{
+		ISRCLookup:               true,
+		MatchAlgorithm:           util.TokenSort,
+		MatchThreshold:           0.3,
+		SearchStrategy:           StrategyInnerTube,
+		QuotaBudget:              5000,
+		InsertMethod:             InsertSingle,
+		SearchPages:              3,
+		DuplicateThreshold:       0.7,
+		DurationToleranceSeconds: 15,
+		DurationRejectSeconds:    90,
+		ReviewThreshold:          0.5,
+		ReviewQueueFile:          "review-queue.json",
+		ReviewCandidates:         5,
+		StateFile:                "sync-state.json",
+		AlbumMode:                AlbumCopy,
+		AlbumVerifyThreshold:     0.7,
+		PlaylistTitle:            "{{.Name}}",
+		PlaylistDescription:      "Playlist created by Spotify Playlist Converter",
+		PlaylistPrivacy:          PrivacyPrivate,
+		PlaylistTags:             []string{"spotify-playlist-converter"},
+		PlaylistItemLimit:        5000,
+		CopyCoverArt:             true,
+		Transliterate:            true,
+	}
+}
+
+// Load reads a JSON configuration file over the Default Config. An empty path
+// returns the Default Config.
+func Load(path string) (*Config, error) {
+	config := Default()
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file [%s]: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("unable to parse config file [%s]: %w", path, err)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file [%s]: %w", path, err)
+	}
+
+	return config, nil
+}
+
+// Matcher returns the Matcher which scores titles for the run, with the
+// MatchAlgorithm and a Normalizer following Transliterate
+func (c *Config) Matcher() util.Matcher {
+	return util.Matcher{Algorithm: c.MatchAlgorithm, Normalizer: &util.Normalizer{Transliterate: c.Transliterate}}
+}
+
+func (c *Config) validate() error {
+	if _, err := util.ParseAlgorithm(string(c.MatchAlgorithm)); err != nil {
+		return err
+	}
+
+	thresholds := map[string]float64{
+		"matchThreshold":       c.MatchThreshold,
+		"duplicateThreshold":   c.DuplicateThreshold,
+		"dedupeThreshold":      c.DedupeThreshold,
+		"reviewThreshold":      c.ReviewThreshold,
+		"albumVerifyThreshold": c.AlbumVerifyThreshold,
+	}
+	for name, threshold := range thresholds {
+		if threshold < 0 || threshold > 1 {
+			return fmt.Errorf("%s must be between 0 and 1", name)
+		}
+	}
+	if c.DedupeThreshold > 0 && c.DedupeThreshold < MinDedupeThreshold {
+		return fmt.Errorf("dedupeThreshold must be 0 or at least %g", MinDedupeThreshold)
+	}
+
+	switch c.SearchStrategy {
+	case StrategyInnerTube, StrategyDataAPI, StrategyHybrid:
+	default:
+		return fmt.Errorf("searchStrategy must be one of [%s, %s, %s]", StrategyInnerTube, StrategyDataAPI, StrategyHybrid)
+	}
+
+	switch c.InsertMethod {
+	case InsertSingle, InsertBatch, InsertInnerTube:
+	default:
+		return fmt.Errorf("insertMethod must be one of [%s, %s, %s]", InsertSingle, InsertBatch, InsertInnerTube)
+	}
+
+	if c.QuotaBudget < 0 {
+		return errors.New("quotaBudget must not be negative")
+	}
+
+	if c.SearchPages < 1 {
+		return errors.New("searchPages must be at least 1")
+	}
+
+	if c.ReviewCandidates < 0 {
+		return errors.New("reviewCandidates must not be negative")
+	}
+
+	switch c.AlbumMode {
+	case AlbumLink, AlbumCopy, AlbumTracks:
+	default:
+		return fmt.Errorf("albumMode must be one of [%s, %s, %s]", AlbumLink, AlbumCopy, AlbumTracks)
+	}
+
+	switch c.PlaylistPrivacy {
+	case PrivacyPrivate, PrivacyUnlisted, PrivacyPublic:
+	default:
+		return fmt.Errorf("playlistPrivacy must be one of [%s, %s, %s]", PrivacyPrivate, PrivacyUnlisted, PrivacyPublic)
+	}
+
+	if c.PlaylistItemLimit < 1 || c.PlaylistItemLimit > 5000 {
+		return errors.New("playlistItemLimit must be between 1 and 5000")
+	}
+
+	if err := c.Rules.Validate(); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(c.PlaylistTitle) == "" {
+		return errors.New("playlistTitle must not be empty")
+	}
+	templates := map[string]string{"playlistTitle": c.PlaylistTitle, "playlistDescription": c.PlaylistDescription}
+	for name, text := range templates {
+		if _, err := template.New(name).Parse(text); err != nil {
+			return fmt.Errorf("%s is not a valid template: %w", name, err)
+		}
+	}
+	title, _ := template.New("playlistTitle").Parse(c.PlaylistTitle)
+	if err := ValidateTitle(title); err != nil {
+		return err
+	}
+
+	if c.DurationToleranceSeconds < 0 || c.DurationRejectSeconds < 0 {
+		return errors.New("duration limits must not be negative")
+	}
+	if c.DurationRejectSeconds > 0 && c.DurationRejectSeconds < c.DurationToleranceSeconds {
+		return errors.New("durationRejectSeconds must not be less than durationToleranceSeconds")
+	}
+
+	return nil
+}
+
+// ValidateTitle rejects a playlistTitle template which uses .Date, in its own
+// text or in any template it defines. Playlists are found again by their
+// title, which must not change from one day to the next.
+func ValidateTitle(title *template.Template) error {
+	for _, tmpl := range title.Templates() {
+		if tmpl.Tree != nil && usesField(tmpl.Root, "Date") {
+			return errors.New("playlistTitle must not use .Date")
+		}
+	}
+	return nil
+}
+
+// usesField reports whether a template node refers to a field of its data
+func usesField(node parse.Node, field string) bool {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return false
+		}
+		for _, child := range node.Nodes {
+			if usesField(child, field) {
+				return true
+			}
+		}
+	case *parse.ActionNode:
+		return usesField(node.Pipe, field)
+	case *parse.IfNode:
+		return usesField(&node.BranchNode, field)
+	case *parse.RangeNode:
+		return usesField(&node.BranchNode, field)
+	case *parse.WithNode:
+		return usesField(&node.BranchNode, field)
+	case *parse.BranchNode:
+		return usesField(node.Pipe, field) || usesField(node.List, field) || usesField(node.ElseList, field)
+	case *parse.PipeNode:
+		if node == nil {
+			return false
+		}
+		for _, command := range node.Cmds {
+			if usesField(command, field) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range node.Args {
+			if usesField(arg, field) {
+				return true
+			}
+		}
+	case *parse.TemplateNode:
+		return usesField(node.Pipe, field)
+	case *parse.ChainNode:
+		return usesField(node.Node, field) || slices.Contains(node.Field, field)
+	case *parse.FieldNode:
+		return slices.Contains(node.Ident, field)
+	case *parse.VariableNode:
+		return slices.Contains(node.Ident, field)
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/rules"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		change func(c *Config)
+		want   string
+	}{
+		"default":                   {func(c *Config) {}, ""},
+		"unknown algorithm":         {func(c *Config) { c.MatchAlgorithm = "soundex" }, "unknown similarity algorithm"},
+		"negative threshold":        {func(c *Config) { c.MatchThreshold = -0.1 }, "matchThreshold must be between 0 and 1"},
+		"threshold above one":       {func(c *Config) { c.DuplicateThreshold = 1.1 }, "duplicateThreshold must be between 0 and 1"},
+		"review threshold":          {func(c *Config) { c.ReviewThreshold = 2 }, "reviewThreshold must be between 0 and 1"},
+		"album threshold":           {func(c *Config) { c.AlbumVerifyThreshold = -1 }, "albumVerifyThreshold must be between 0 and 1"},
+		"dedupe threshold too low":  {func(c *Config) { c.DedupeThreshold = 0.5 }, "dedupeThreshold must be 0 or at least"},
+		"dedupe threshold above 1":  {func(c *Config) { c.DedupeThreshold = 1.5 }, "dedupeThreshold must be between 0 and 1"},
+		"dedupe threshold":          {func(c *Config) { c.DedupeThreshold = MinDedupeThreshold }, ""},
+		"unknown search strategy":   {func(c *Config) { c.SearchStrategy = "scrape" }, "searchStrategy must be one of"},
+		"unknown insert method":     {func(c *Config) { c.InsertMethod = "bulk" }, "insertMethod must be one of"},
+		"negative quota budget":     {func(c *Config) { c.QuotaBudget = -1 }, "quotaBudget must not be negative"},
+		"no search pages":           {func(c *Config) { c.SearchPages = 0 }, "searchPages must be at least 1"},
+		"negative review candidate": {func(c *Config) { c.ReviewCandidates = -1 }, "reviewCandidates must not be negative"},
+		"unknown album mode":        {func(c *Config) { c.AlbumMode = "merge" }, "albumMode must be one of"},
+		"unknown privacy":           {func(c *Config) { c.PlaylistPrivacy = "secret" }, "playlistPrivacy must be one of"},
+		"no item limit":             {func(c *Config) { c.PlaylistItemLimit = 0 }, "playlistItemLimit must be between 1 and 5000"},
+		"item limit too high":       {func(c *Config) { c.PlaylistItemLimit = 5001 }, "playlistItemLimit must be between 1 and 5000"},
+		"invalid rules":             {func(c *Config) { c.Rules = rules.Rules{Explicit: "sometimes"} }, "rules.explicit must be one of"},
+		"empty title":               {func(c *Config) { c.PlaylistTitle = "  " }, "playlistTitle must not be empty"},
+		"invalid title":             {func(c *Config) { c.PlaylistTitle = "{{.Name" }, "playlistTitle is not a valid template"},
+		"invalid description":       {func(c *Config) { c.PlaylistDescription = "{{if}}" }, "playlistDescription is not a valid template"},
+		"negative tolerance":        {func(c *Config) { c.DurationToleranceSeconds = -1 }, "duration limits must not be negative"},
+		"negative reject":           {func(c *Config) { c.DurationRejectSeconds = -1 }, "duration limits must not be negative"},
+		"reject below tolerance": {func(c *Config) {
+			c.DurationToleranceSeconds, c.DurationRejectSeconds = 30, 10
+		}, "durationRejectSeconds must not be less than durationToleranceSeconds"},
+		"reject disabled": {func(c *Config) { c.DurationRejectSeconds = 0 }, ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := Default()
+			test.change(config)
+
+			err := config.validate()
+			switch {
+			case test.want == "" && err != nil:
+				t.Fatalf("expected no error, got %v", err)
+			case test.want != "" && (err == nil || !strings.Contains(err.Error(), test.want)):
+				t.Fatalf("expected an error containing %q, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateTitleDate(t *testing.T) {
+	tests := map[string]struct {
+		title string
+		valid bool
+	}{
+		"name":                 {"{{.Name}}", true},
+		"name and owner":       {"{{.Name}} by {{.Owner}}", true},
+		"if":                   {"{{if .Description}}{{.Name}}{{end}}", true},
+		"date":                 {"{{.Name}} {{.Date}}", false},
+		"root variable":        {"{{.Name}} {{$.Date}}", false},
+		"pipeline":             {"{{.Date | printf \"%s\"}}", false},
+		"function argument":    {"{{printf \"%s %s\" .Name .Date}}", false},
+		"if date":              {"{{if .Date}}{{.Name}}{{end}}", false},
+		"if body":              {"{{if .Name}}{{.Date}}{{end}}", false},
+		"else":                 {"{{if .Owner}}{{.Name}}{{else}}{{.Date}}{{end}}", false},
+		"range":                {"{{range .Date}}{{.}}{{end}}", false},
+		"with":                 {"{{with .Name}}{{$.Date}}{{end}}", false},
+		"with else":            {"{{with .Owner}}{{.}}{{else}}{{.Date}}{{end}}", false},
+		"variable":             {"{{$date := .Date}}{{.Name}}", false},
+		"define":               {`{{define "when"}}{{.Date}}{{end}}{{.Name}}`, false},
+		"template argument":    {`{{define "text"}}{{.}}{{end}}{{template "text" .Date}}`, false},
+		"block":                {`{{block "when" .}}{{.Date}}{{end}}`, false},
+		"template of the name": {`{{define "name"}}{{.Name}}{{end}}{{template "name" .}}`, true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := Default()
+			config.PlaylistTitle = test.title
+
+			err := config.validate()
+			if test.valid && err != nil {
+				t.Fatalf("expected %q to be allowed, got %v", test.title, err)
+			}
+			if !test.valid && (err == nil || !strings.Contains(err.Error(), "playlistTitle must not use .Date")) {
+				t.Fatalf("expected %q to be rejected, got %v", test.title, err)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"matchThreshold": 0.6, "playlistTitle": "{{.Name}} {{.Date}}"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "invalid config file") {
+		t.Fatalf("expected an invalid config file, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"matchThreshold": 0.6}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if config.MatchThreshold != 0.6 || config.SearchPages != Default().SearchPages {
+		t.Errorf("expected the file over the defaults, got %+v", config)
+	}
+}
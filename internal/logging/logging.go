@@ -0,0 +1,114 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"sync/atomic"
+)
+
+// Options controls the verbosity and format of the log output
+type Options struct {
+	Verbose bool
+	Quiet   bool
+	JSON    bool
+}
+
+var (
+	level   = new(slog.LevelVar)
+	handler atomic.Pointer[slog.Handler]
+)
+
+func init() {
+	Setup(Options{}, os.Stderr)
+}
+
+// Setup configures the handler shared by every component logger, and the
+// default slog logger.
+func Setup(opts Options, out io.Writer) {
+	switch {
+	case opts.Verbose:
+		level.Set(slog.LevelDebug)
+	case opts.Quiet:
+		level.Set(slog.LevelWarn)
+	default:
+		level.Set(slog.LevelInfo)
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: level}
+
+	var h slog.Handler
+	if opts.JSON {
+		h = slog.NewJSONHandler(out, handlerOpts)
+	} else {
+		h = slog.NewTextHandler(out, handlerOpts)
+	}
+
+	handler.Store(&h)
+	slog.SetDefault(slog.New(h))
+}
+
+// For returns the logger for a component. Loggers may be created before Setup
+// is called, as they always write through the current handler.
+func For(component string) *slog.Logger {
+	return slog.New(&dynamicHandler{}).With("component", component)
+}
+
+// Fatal logs msg at Error level, then exits
+func Fatal(logger *slog.Logger, msg string, args ...any) {
+	logger.Error(msg, args...)
+	os.Exit(1)
+}
+
+// dynamicHandler resolves the handler configured by Setup on every call,
+// replaying any attributes and groups added to the logger.
+type dynamicHandler struct {
+	wrappers []func(slog.Handler) slog.Handler
+}
+
+func (d *dynamicHandler) current() slog.Handler {
+	h := *handler.Load()
+	for _, wrap := range d.wrappers {
+		h = wrap(h)
+	}
+	return h
+}
+
+func (d *dynamicHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= level.Level()
+}
+
+func (d *dynamicHandler) Handle(ctx context.Context, r slog.Record) error {
+	return d.current().Handle(ctx, r)
+}
+
+func (d *dynamicHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return d.with(func(h slog.Handler) slog.Handler { return h.WithAttrs(attrs) })
+}
+
+func (d *dynamicHandler) WithGroup(name string) slog.Handler {
+	return d.with(func(h slog.Handler) slog.Handler { return h.WithGroup(name) })
+}
+
+func (d *dynamicHandler) with(wrap func(slog.Handler) slog.Handler) slog.Handler {
+	wrappers := make([]func(slog.Handler) slog.Handler, 0, len(d.wrappers)+1)
+	wrappers = append(wrappers, d.wrappers...)
+	return &dynamicHandler{wrappers: append(wrappers, wrap)}
+}
@@ -0,0 +1,82 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package logging
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const redacted = "REDACTED"
+
+var secretParams = []string{"key", "access_token", "token", "client_secret", "code"}
+
+var secretHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "X-Goog-Api-Key", "X-Goog-Visitor-Id"}
+
+// RedactURL returns the URL as a string, with any API key or token in its
+// query replaced. Parameter names are matched ignoring case.
+func RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	query := u.Query()
+	for name := range query {
+		if isSecret(name, secretParams) {
+			query.Set(name, redacted)
+		}
+	}
+
+	safe := *u
+	safe.User = nil
+	safe.RawQuery = query.Encode()
+	return safe.String()
+}
+
+// RedactHeaders returns a copy of the headers with credentials and cookies
+// replaced. Header names are matched ignoring case, as headers set directly on
+// the map are not canonicalised. Bearer tokens keep their scheme so the dump
+// still shows which kind of authorisation was sent.
+func RedactHeaders(headers http.Header) http.Header {
+	safe := headers.Clone()
+	for name, values := range safe {
+		if !isSecret(name, secretHeaders) {
+			continue
+		}
+
+		authorization := strings.EqualFold(name, "Authorization")
+		for idx, value := range values {
+			if scheme, _, ok := strings.Cut(value, " "); ok && authorization {
+				values[idx] = scheme + " " + redacted
+			} else {
+				values[idx] = redacted
+			}
+		}
+	}
+	return safe
+}
+
+// isSecret reports whether name is one of the secret names, ignoring case
+func isSecret(name string, secrets []string) bool {
+	for _, secret := range secrets {
+		if strings.EqualFold(name, secret) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteTable writes the Report as plain text tables, for the terminal
+func (r *Report) WriteTable(w io.Writer) error {
+	for _, playlist := range r.Playlists {
+		fmt.Fprintf(w, "\n%s  %s\n", playlist.Name, playlist.URL())
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "#\tSPOTIFY TRACK\tYOUTUBE VIDEO\tLINK\tSCORE\tMETHOD\tOUTCOME\tERROR")
+		for idx, track := range playlist.Tracks {
+			fmt.Fprintf(tw, "%d\t%s - %s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				idx+1, oneLine(track.Artist), oneLine(track.Name), oneLine(track.VideoTitle), track.VideoURL(), formatScore(track.Score), track.Method, track.Outcome, oneLine(track.Error))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(w, "%s. Credits: %d\n", formatTotals(playlist.Totals()), playlist.Credits)
+	}
+
+	_, err := fmt.Fprintf(w, "\nTotal: %s. Credits: %d\n", formatTotals(r.Totals()), r.Credits)
+	return err
+}
+
+// WriteMarkdown writes the Report as a Markdown document
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Conversion Report\n\n")
+	fmt.Fprintf(&b, "Run started %s, finished %s.\n\n", r.Started.Format("2006-01-02 15:04:05"), r.Finished.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(&b, "%s. YouTube Credits used: %d.\n", formatTotals(r.Totals()), r.Credits)
+
+	for _, playlist := range r.Playlists {
+		fmt.Fprintf(&b, "\n## %s\n\n", escapeMarkdown(playlist.Name))
+		if url := playlist.URL(); url != "" {
+			fmt.Fprintf(&b, "YouTube Playlist: <%s>\n\n", url)
+		}
+		fmt.Fprintf(&b, "%s. YouTube Credits used: %d.\n\n", formatTotals(playlist.Totals()), playlist.Credits)
+
+		fmt.Fprintln(&b, "| # | Spotify Track | YouTube Video | Score | Method | Outcome | Error |")
+		fmt.Fprintln(&b, "|---|---|---|---|---|---|---|")
+		for idx, track := range playlist.Tracks {
+			video := escapeMarkdown(track.VideoTitle)
+			if url := track.VideoURL(); url != "" {
+				video = fmt.Sprintf("[%s](%s)", video, url)
+			}
+			fmt.Fprintf(&b, "| %d | %s - %s | %s | %s | %s | %s | %s |\n",
+				idx+1, escapeMarkdown(track.Artist), escapeMarkdown(track.Name), video, formatScore(track.Score), track.Method, track.Outcome, escapeMarkdown(track.Error))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the Report as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(struct {
+		*Report
+		Totals map[Outcome]int `json:"totals"`
+	}{r, r.Totals()})
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"add1":   func(i int) int { return i + 1 },
+	"score":  formatScore,
+	"totals": formatTotals,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Conversion Report</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+.inserted { background: #e6ffed; }
+.duplicate { background: #f1f8ff; }
+.review { background: #fffbdd; }
+.not-found, .failed { background: #ffeef0; }
+</style>
+</head>
+<body>
+<h1>Conversion Report</h1>
+<p>Run started {{.Started.Format "2006-01-02 15:04:05"}}, finished {{.Finished.Format "2006-01-02 15:04:05"}}.</p>
+<p>{{totals .Totals}}. YouTube Credits used: {{.Credits}}.</p>
+{{range .Playlists}}
+<h2>{{.Name}}</h2>
+{{with .URL}}<p>YouTube Playlist: <a href="{{.}}">{{.}}</a></p>{{end}}
+<p>{{totals .Totals}}. YouTube Credits used: {{.Credits}}.</p>
+<table>
+<tr><th>#</th><th>Spotify Track</th><th>YouTube Video</th><th>Score</th><th>Method</th><th>Outcome</th><th>Error</th></tr>
+{{range $idx, $track := .Tracks}}<tr class="{{.Outcome}}"><td>{{add1 $idx}}</td><td>{{.Artist}} - {{.Name}}</td><td>{{with .VideoURL}}<a href="{{.}}">{{$track.VideoTitle}}</a>{{else}}{{.VideoTitle}}{{end}}</td><td>{{score .Score}}</td><td>{{.Method}}</td><td>{{.Outcome}}</td><td>{{.Error}}</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the Report as a standalone HTML page
+func (r *Report) WriteHTML(w io.Writer) error {
+	return htmlTemplate.Execute(w, r)
+}
+
+func formatScore(score float64) string {
+	if score == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%.2f", score)
+}
+
+func formatTotals(totals map[Outcome]int) string {
+	parts := make([]string, 0, len(Outcomes))
+	for _, outcome := range Outcomes {
+		parts = append(parts, fmt.Sprintf("%s: %d", outcome, totals[outcome]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "[", "\\[", "]", "\\]", "*", "\\*", "_", "\\_", "`", "\\`")
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(oneLine(text))
+}
+
+// oneLine collapses line breaks, tabs and runs of spaces into single spaces,
+// so that a multi-line API error stays within one table row
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
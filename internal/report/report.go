@@ -0,0 +1,165 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package report
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Outcome is what happened to a single Spotify Track during a run
+type Outcome string
+
+const (
+	Inserted  Outcome = "inserted"
+	Duplicate Outcome = "duplicate"
+	Review    Outcome = "review"
+	NotFound  Outcome = "not-found"
+	Failed    Outcome = "failed"
+	// Linked Tracks are covered by an official YouTube Music album playlist,
+	// so were not added to a Playlist of their own
+	Linked Outcome = "linked"
+)
+
+// Outcomes lists every Outcome in the order they are reported
+var Outcomes = []Outcome{Inserted, Linked, Duplicate, Review, NotFound, Failed}
+
+// Track is the result of converting one Spotify Track
+type Track struct {
+	SpotifyId  string  `json:"spotifyId"`
+	Artist     string  `json:"artist"`
+	Name       string  `json:"name"`
+	VideoId    string  `json:"videoId,omitempty"`
+	VideoTitle string  `json:"videoTitle,omitempty"`
+	Score      float64 `json:"score,omitempty"`
+	Method     string  `json:"method,omitempty"`
+	Outcome    Outcome `json:"outcome"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// VideoURL returns the watch page of the matched video, if there is one
+func (t *Track) VideoURL() string {
+	if t.VideoId == "" {
+		return ""
+	}
+	return "https://www.youtube.com/watch?v=" + t.VideoId
+}
+
+// Playlist is the result of converting one Spotify Playlist
+type Playlist struct {
+	Name       string   `json:"name"`
+	SpotifyId  string   `json:"spotifyId"`
+	YouTubeId  string   `json:"youtubeId,omitempty"`
+	Tracks     []*Track `json:"tracks"`
+	Credits    int      `json:"credits"`
+	creditsRef int
+}
+
+// URL returns the YouTube Playlist page
+func (p *Playlist) URL() string {
+	if p.YouTubeId == "" {
+		return ""
+	}
+	return "https://www.youtube.com/playlist?list=" + p.YouTubeId
+}
+
+// Add records a Track result in the Playlist
+func (p *Playlist) Add(track *Track) *Track {
+	p.Tracks = append(p.Tracks, track)
+	return track
+}
+
+// Totals counts the Tracks in the Playlist by Outcome
+func (p *Playlist) Totals() map[Outcome]int {
+	totals := make(map[Outcome]int)
+	for _, track := range p.Tracks {
+		totals[track.Outcome]++
+	}
+	return totals
+}
+
+// Finish records the Credits used since the Playlist was started
+func (p *Playlist) Finish(credits int) {
+	p.Credits = credits - p.creditsRef
+}
+
+// Report is the result of a whole run
+type Report struct {
+	Started   time.Time   `json:"started"`
+	Finished  time.Time   `json:"finished"`
+	Playlists []*Playlist `json:"playlists"`
+	Credits   int         `json:"credits"`
+}
+
+// New starts a Report
+func New() *Report {
+	return &Report{Started: time.Now()}
+}
+
+// StartPlaylist adds a Playlist to the Report. Credits is the running total of
+// YouTube Credits used, so the Playlist can record its own share.
+func (r *Report) StartPlaylist(name, spotifyId string, credits int) *Playlist {
+	playlist := &Playlist{Name: name, SpotifyId: spotifyId, creditsRef: credits}
+	r.Playlists = append(r.Playlists, playlist)
+	return playlist
+}
+
+// Finish records the end of the run and the total Credits used
+func (r *Report) Finish(credits int) {
+	r.Finished = time.Now()
+	r.Credits = credits
+}
+
+// Totals counts every Track in the Report by Outcome
+func (r *Report) Totals() map[Outcome]int {
+	totals := make(map[Outcome]int)
+	for _, playlist := range r.Playlists {
+		for outcome, count := range playlist.Totals() {
+			totals[outcome] += count
+		}
+	}
+	return totals
+}
+
+// WriteFile writes the Report in the format matching the file extension:
+// .md, .html, .json, or a plain text table for anything else.
+func (r *Report) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		err = r.WriteMarkdown(f)
+	case ".html", ".htm":
+		err = r.WriteHTML(f)
+	case ".json":
+		err = r.WriteJSON(f)
+	default:
+		err = r.WriteTable(f)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write report [%s]: %w", path, err)
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,189 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package report
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// hostileTitle is a video title as an uploader might choose it
+const hostileTitle = `Song <script>alert("x")</script> | [link](javascript:alert(1)) "><img src=x onerror=alert(1)>`
+
+func testReport() *Report {
+	r := &Report{Started: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	playlist := r.StartPlaylist("Road Trip", "spotifyplaylist1", 10)
+	playlist.YouTubeId = "PLroadtrip"
+	playlist.Add(&Track{SpotifyId: "track1", Artist: "Band", Name: "Alpha", VideoId: "alphavideo1", VideoTitle: "Band - Alpha", Score: 0.91, Method: "innertube", Outcome: Inserted})
+	playlist.Add(&Track{SpotifyId: "track2", Artist: "Band", Name: "Beta", VideoId: "betavideo01", VideoTitle: hostileTitle, Score: 0.42, Method: "innertube", Outcome: Review})
+	playlist.Add(&Track{SpotifyId: "track3", Artist: "Band", Name: "Gamma", Outcome: NotFound})
+	playlist.Add(&Track{SpotifyId: "track4", Artist: "Band", Name: "Delta", VideoId: "deltavideo1", Outcome: Failed, Error: "quotaExceeded"})
+	playlist.Finish(220)
+
+	r.Finished = r.Started.Add(90 * time.Second)
+	r.Credits = 220
+	return r
+}
+
+func TestWriteTable(t *testing.T) {
+	var b bytes.Buffer
+	if err := testReport().WriteTable(&b); err != nil {
+		t.Fatalf("WriteTable: %v", err)
+	}
+
+	for _, want := range []string{
+		"Road Trip  https://www.youtube.com/playlist?list=PLroadtrip",
+		"https://www.youtube.com/watch?v=alphavideo1",
+		"0.91",
+		"quotaExceeded",
+		"inserted: 1, linked: 0, duplicate: 0, review: 1, not-found: 1, failed: 1. Credits: 210",
+		"Total: inserted: 1",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("expected [%s] in:\n%s", want, b.String())
+		}
+	}
+}
+
+func TestMultiLineErrorsStayInOneRow(t *testing.T) {
+	r := New()
+	playlist := r.StartPlaylist("Mix", "spotifymix1", 0)
+	playlist.Add(&Track{SpotifyId: "track1", Artist: "Band", Name: "Alpha", Outcome: Failed,
+		Error: "googleapi: Error 403: The request cannot be completed.\nMore details:\nReason: quotaExceeded, Message: Quota\texceeded"})
+	playlist.Finish(0)
+	r.Finish(0)
+
+	var table, markdown strings.Builder
+	if err := r.WriteTable(&table); err != nil {
+		t.Fatalf("WriteTable: %v", err)
+	}
+	if err := r.WriteMarkdown(&markdown); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+
+	want := "googleapi: Error 403: The request cannot be completed. More details: Reason: quotaExceeded, Message: Quota exceeded"
+	for name, out := range map[string]string{"table": table.String(), "markdown": markdown.String()} {
+		var row string
+		for _, line := range strings.Split(out, "\n") {
+			if strings.Contains(line, "Alpha") {
+				row = line
+			}
+		}
+		if !strings.Contains(row, want) {
+			t.Errorf("expected the %s row to hold the whole error, got:\n%s", name, out)
+		}
+	}
+}
+
+func TestWriteMarkdownEscapesTitles(t *testing.T) {
+	var b bytes.Buffer
+	if err := testReport().WriteMarkdown(&b); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+
+	if strings.Contains(b.String(), "[link](javascript") {
+		t.Errorf("expected the video title's link to be escaped:\n%s", b.String())
+	}
+	// Every table row keeps its seven columns despite the pipe in the title
+	for _, line := range strings.Split(b.String(), "\n") {
+		if strings.HasPrefix(line, "| 2 |") {
+			if columns := strings.Count(line, "|") - strings.Count(line, `\|`); columns != 8 {
+				t.Errorf("expected 8 column separators, got [%d] in [%s]", columns, line)
+			}
+		}
+	}
+	if !strings.Contains(b.String(), "YouTube Playlist: <https://www.youtube.com/playlist?list=PLroadtrip>") {
+		t.Errorf("expected the Playlist link in:\n%s", b.String())
+	}
+}
+
+func TestWriteHTMLEscapesTitles(t *testing.T) {
+	var b bytes.Buffer
+	if err := testReport().WriteHTML(&b); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+
+	for _, unsafe := range []string{"<script>", "<img"} {
+		if strings.Contains(b.String(), unsafe) {
+			t.Errorf("expected [%s] to be escaped:\n%s", unsafe, b.String())
+		}
+	}
+	if !strings.Contains(b.String(), "&lt;script&gt;") {
+		t.Errorf("expected the escaped title in:\n%s", b.String())
+	}
+	if !strings.Contains(b.String(), `<tr class="review">`) || !strings.Contains(b.String(), `<a href="https://www.youtube.com/watch?v=alphavideo1">Band - Alpha</a>`) {
+		t.Errorf("expected the rows to be rendered:\n%s", b.String())
+	}
+}
+
+func TestWriteJSONGolden(t *testing.T) {
+	var b bytes.Buffer
+	if err := testReport().WriteJSON(&b); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "report.json")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, b.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("missing golden file, run with -update: %v", err)
+	}
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("result does not match [%s]\ngot:\n%s\nwant:\n%s", golden, b.Bytes(), want)
+	}
+}
+
+func TestWriteFileChoosesFormat(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string]string{
+		"report.md":   "# Conversion Report",
+		"report.HTML": "<!DOCTYPE html>",
+		"report.json": `"playlists": [`,
+		"report.txt":  "Total: inserted: 1",
+	}
+
+	for name, want := range tests {
+		path := filepath.Join(dir, name)
+		if err := testReport().WriteFile(path); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s: expected [%s] in:\n%s", name, want, data)
+		}
+	}
+}
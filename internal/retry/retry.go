@@ -0,0 +1,281 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package retry
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
+	"google.golang.org/api/googleapi"
+)
+
+var logger = logging.For("retry")
+
+// Class describes how a failed call should be handled
+type Class int
+
+const (
+	// Retryable errors are transient, and the call may be repeated after a delay
+	Retryable Class = iota
+	// Terminal errors will not succeed on retry, and should stop the current operation
+	Terminal
+	// Skip errors only affect the current item, which should be skipped
+	Skip
+)
+
+func (c Class) String() string {
+	switch c {
+	case Retryable:
+		return "retryable"
+	case Skip:
+		return "skip"
+	default:
+		return "terminal"
+	}
+}
+
+var retryableReasons = map[string]bool{
+	"backendError":          true,
+	"internalError":         true,
+	"rateLimitExceeded":     true,
+	"userRateLimitExceeded": true,
+	"SERVICE_UNAVAILABLE":   true,
+	"UNAVAILABLE":           true,
+}
+
+// rateLimitReasons are refusals made before a call is applied
+var rateLimitReasons = map[string]bool{
+	"rateLimitExceeded":     true,
+	"userRateLimitExceeded": true,
+}
+
+var terminalReasons = map[string]bool{
+	"quotaExceeded":      true,
+	"dailyLimitExceeded": true,
+	"forbidden":          true,
+}
+
+var skipReasons = map[string]bool{
+	"playlistItemsNotAccessible": true,
+	"videoNotFound":              true,
+}
+
+// StatusError is implemented by errors that carry an HTTP Status Code, and
+// optionally a set of API Error Reasons and a Retry-After hint.
+type StatusError interface {
+	error
+	StatusCode() int
+	Reasons() []string
+	RetryAfter() time.Duration
+}
+
+// Policy controls how many times, and how quickly, a call is retried
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+
+	// Sleep is used to wait between attempts. Defaults to time.Sleep.
+	Sleep func(time.Duration)
+}
+
+var DefaultPolicy = Policy{
+	MaxAttempts: 5,
+	BaseDelay:   time.Second,
+	MaxDelay:    32 * time.Second,
+}
+
+// Do calls fn until it succeeds, returns an error which is not Retryable, or
+// the Policy runs out of attempts. The last error returned by fn is returned.
+func Do(policy Policy, fn func() error) error {
+	return policy.run(fn, true, nil)
+}
+
+// DoNonIdempotent calls fn like Do, for calls which must not be applied twice,
+// such as inserts. A refusal by a rate limit is always retried, but a timeout
+// or server error may arrive after the server has applied the call. Before
+// such a call is repeated, applied reports whether it took effect, in which
+// case nil is returned. With a nil applied, such calls are not repeated.
+func DoNonIdempotent(policy Policy, fn func() error, applied func() bool) error {
+	return policy.run(fn, false, applied)
+}
+
+func (p Policy) run(fn func() error, idempotent bool, applied func() bool) error {
+	sleep := p.Sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		if Classify(err) != Retryable || attempt+1 >= p.MaxAttempts {
+			return err
+		}
+		checkApplied := !idempotent && !RateLimited(err)
+		if checkApplied && applied == nil {
+			return err
+		}
+
+		delay := p.backoff(attempt)
+		if retryAfter := RetryAfter(err); retryAfter > delay {
+			// The server's hint is trusted no further than the longest backoff
+			delay = min(retryAfter, max(p.MaxDelay, delay))
+		}
+
+		logger.Warn("Call failed, retrying", "attempt", attempt+1, "maxAttempts", p.MaxAttempts, "delay", delay, "error", err)
+		sleep(delay)
+
+		if checkApplied && applied() {
+			logger.Info("Call was applied despite failing, not repeating it", "error", err)
+			return nil
+		}
+	}
+}
+
+// backoff returns a fully jittered exponential delay for the given attempt
+func (p Policy) backoff(attempt int) time.Duration {
+	ceiling := p.BaseDelay << attempt
+	if ceiling <= 0 || ceiling > p.MaxDelay {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling) + 1
+}
+
+// Classify determines whether err is Retryable, Terminal, or should Skip the
+// current item. Error Reasons take precedence over the Status Code, as the
+// YouTube API reports both quota exhaustion and rate limiting as a 403.
+func Classify(err error) Class {
+	if err == nil {
+		return Terminal
+	}
+
+	code, reasons, ok := inspect(err)
+	if !ok {
+		var netErr net.Error
+		if errors.As(err, &netErr) {
+			return Retryable
+		}
+		return Terminal
+	}
+
+	for _, reason := range reasons {
+		switch {
+		case skipReasons[reason]:
+			return Skip
+		case terminalReasons[reason]:
+			return Terminal
+		case retryableReasons[reason]:
+			return Retryable
+		}
+	}
+
+	switch {
+	case code == http.StatusTooManyRequests, code >= 500:
+		return Retryable
+	case code == http.StatusNotFound:
+		return Skip
+	default:
+		return Terminal
+	}
+}
+
+// RateLimited reports whether err is a refusal for exceeding a rate limit,
+// which the server makes before applying the call
+func RateLimited(err error) bool {
+	code, reasons, ok := inspect(err)
+	if !ok {
+		return false
+	}
+
+	for _, reason := range reasons {
+		if rateLimitReasons[reason] {
+			return true
+		}
+		if skipReasons[reason] || terminalReasons[reason] || retryableReasons[reason] {
+			return false
+		}
+	}
+
+	return code == http.StatusTooManyRequests
+}
+
+// RetryAfter returns the delay requested by the server, if any
+func RetryAfter(err error) time.Duration {
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.RetryAfter()
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return ParseRetryAfter(apiErr.Header.Get("Retry-After"))
+	}
+
+	return 0
+}
+
+// ParseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func ParseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay
+		}
+	}
+
+	return 0
+}
+
+func inspect(err error) (int, []string, bool) {
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode(), statusErr.Reasons(), true
+	}
+
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		var reasons []string
+		for _, item := range apiErr.Errors {
+			reasons = append(reasons, item.Reason)
+		}
+		return apiErr.Code, reasons, true
+	}
+
+	return 0, nil, false
+}
@@ -0,0 +1,246 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func apiError(code int, reasons ...string) *googleapi.Error {
+	err := &googleapi.Error{Code: code, Header: http.Header{}}
+	for _, reason := range reasons {
+		err.Errors = append(err.Errors, googleapi.ErrorItem{Reason: reason})
+	}
+	return err
+}
+
+func TestClassify(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want Class
+	}{
+		"nil":                {nil, Terminal},
+		"plain":              {errors.New("boom"), Terminal},
+		"network":            {&net.DNSError{IsTimeout: true}, Retryable},
+		"wrapped network":    {fmt.Errorf("calling: %w", &net.OpError{Op: "dial", Err: errors.New("refused")}), Retryable},
+		"rate limit reason":  {apiError(http.StatusForbidden, "rateLimitExceeded"), Retryable},
+		"user rate limit":    {apiError(http.StatusForbidden, "userRateLimitExceeded"), Retryable},
+		"backend reason":     {apiError(http.StatusInternalServerError, "backendError"), Retryable},
+		"quota reason":       {apiError(http.StatusForbidden, "quotaExceeded"), Terminal},
+		"daily limit reason": {apiError(http.StatusForbidden, "dailyLimitExceeded"), Terminal},
+		"forbidden reason":   {apiError(http.StatusForbidden, "forbidden"), Terminal},
+		"video not found":    {apiError(http.StatusNotFound, "videoNotFound"), Skip},
+		"not accessible":     {apiError(http.StatusForbidden, "playlistItemsNotAccessible"), Skip},
+		"reason over code":   {apiError(http.StatusServiceUnavailable, "quotaExceeded"), Terminal},
+		"first known reason": {apiError(http.StatusForbidden, "unknownReason", "videoNotFound", "quotaExceeded"), Skip},
+		"429":                {apiError(http.StatusTooManyRequests), Retryable},
+		"500":                {apiError(http.StatusInternalServerError), Retryable},
+		"503":                {apiError(http.StatusServiceUnavailable), Retryable},
+		"404":                {apiError(http.StatusNotFound), Skip},
+		"400":                {apiError(http.StatusBadRequest), Terminal},
+		"401":                {apiError(http.StatusUnauthorized), Terminal},
+	}
+
+	for name, test := range tests {
+		if got := Classify(test.err); got != test.want {
+			t.Errorf("%s: expected [%s], got [%s]", name, test.want, got)
+		}
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"plain":             {errors.New("boom"), false},
+		"network":           {&net.DNSError{IsTimeout: true}, false},
+		"429":               {apiError(http.StatusTooManyRequests), true},
+		"rate limit reason": {apiError(http.StatusForbidden, "rateLimitExceeded"), true},
+		"backend reason":    {apiError(http.StatusTooManyRequests, "backendError"), false},
+		"503":               {apiError(http.StatusServiceUnavailable), false},
+	}
+
+	for name, test := range tests {
+		if got := RateLimited(test.err); got != test.want {
+			t.Errorf("%s: expected [%v], got [%v]", name, test.want, got)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := Policy{BaseDelay: time.Second, MaxDelay: 8 * time.Second}
+
+	for attempt := range 70 {
+		ceiling := policy.MaxDelay
+		if attempt < 3 {
+			ceiling = policy.BaseDelay << attempt
+		}
+
+		for range 50 {
+			if delay := policy.backoff(attempt); delay <= 0 || delay > ceiling {
+				t.Fatalf("attempt [%d]: delay [%s] outside (0, %s]", attempt, delay, ceiling)
+			}
+		}
+	}
+
+	if delay := (Policy{}).backoff(3); delay != 0 {
+		t.Fatalf("expected no delay without a MaxDelay, got [%s]", delay)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		min, max time.Duration
+	}{
+		"empty":       {"", 0, 0},
+		"seconds":     {"120", 120 * time.Second, 120 * time.Second},
+		"zero":        {"0", 0, 0},
+		"negative":    {"-5", 0, 0},
+		"garbage":     {"soon", 0, 0},
+		"future date": {time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), 50 * time.Second, time.Minute},
+		"past date":   {time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, 0},
+	}
+
+	for name, test := range tests {
+		if got := ParseRetryAfter(test.value); got < test.min || got > test.max {
+			t.Errorf("%s: expected between [%s] and [%s], got [%s]", name, test.min, test.max, got)
+		}
+	}
+}
+
+// testPolicy records the delays slept between attempts
+func testPolicy(slept *[]time.Duration) Policy {
+	return Policy{
+		MaxAttempts: 4,
+		BaseDelay:   time.Second,
+		MaxDelay:    10 * time.Second,
+		Sleep:       func(delay time.Duration) { *slept = append(*slept, delay) },
+	}
+}
+
+// failing returns errs in turn, then succeeds, counting the calls
+func failing(calls *int, errs ...error) func() error {
+	return func() error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestDo(t *testing.T) {
+	tests := map[string]struct {
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		"success":       {nil, 1, false},
+		"transient":     {[]error{apiError(http.StatusServiceUnavailable), apiError(http.StatusTooManyRequests)}, 3, false},
+		"terminal":      {[]error{apiError(http.StatusForbidden, "quotaExceeded")}, 1, true},
+		"skip":          {[]error{apiError(http.StatusNotFound)}, 1, true},
+		"out of tries":  {[]error{apiError(500), apiError(500), apiError(500), apiError(500), apiError(500)}, 4, true},
+		"network error": {[]error{&net.DNSError{IsTimeout: true}}, 2, false},
+	}
+
+	for name, test := range tests {
+		var slept []time.Duration
+		calls := 0
+
+		err := Do(testPolicy(&slept), failing(&calls, test.errs...))
+		if (err != nil) != test.wantErr || calls != test.wantCalls {
+			t.Errorf("%s: expected [%d] calls and error [%v], got [%d] and [%v]", name, test.wantCalls, test.wantErr, calls, err)
+		}
+		if len(slept) != calls-1 && !test.wantErr {
+			t.Errorf("%s: expected [%d] sleeps, got [%d]", name, calls-1, len(slept))
+		}
+	}
+}
+
+func TestDoCapsRetryAfter(t *testing.T) {
+	tests := map[string]struct {
+		retryAfter string
+		min, max   time.Duration
+	}{
+		"within limit": {"5", 5 * time.Second, 5 * time.Second},
+		"hours":        {strconv.Itoa(int((6 * time.Hour).Seconds())), 10 * time.Second, 10 * time.Second},
+		"far date":     {time.Now().Add(48 * time.Hour).UTC().Format(http.TimeFormat), 10 * time.Second, 10 * time.Second},
+	}
+
+	for name, test := range tests {
+		var slept []time.Duration
+		calls := 0
+
+		limited := apiError(http.StatusTooManyRequests)
+		limited.Header.Set("Retry-After", test.retryAfter)
+
+		if err := Do(testPolicy(&slept), failing(&calls, limited)); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(slept) != 1 || slept[0] < test.min || slept[0] > test.max {
+			t.Errorf("%s: expected a delay between [%s] and [%s], got %v", name, test.min, test.max, slept)
+		}
+	}
+}
+
+func TestDoNonIdempotent(t *testing.T) {
+	timeout := &net.DNSError{IsTimeout: true}
+
+	tests := map[string]struct {
+		errs        []error
+		applied     func() bool
+		wantCalls   int
+		wantChecked int
+		wantErr     bool
+	}{
+		"rate limited":      {[]error{apiError(http.StatusTooManyRequests)}, nil, 2, 0, false},
+		"rate limit reason": {[]error{apiError(http.StatusForbidden, "userRateLimitExceeded")}, nil, 2, 0, false},
+		"no check":          {[]error{apiError(http.StatusServiceUnavailable)}, nil, 1, 0, true},
+		"applied":           {[]error{timeout}, func() bool { return true }, 1, 1, false},
+		"not applied":       {[]error{timeout, apiError(http.StatusInternalServerError, "backendError")}, func() bool { return false }, 3, 2, false},
+		"terminal":          {[]error{apiError(http.StatusForbidden, "quotaExceeded")}, func() bool { return true }, 1, 0, true},
+	}
+
+	for name, test := range tests {
+		var slept []time.Duration
+		calls, checked := 0, 0
+
+		var applied func() bool
+		if test.applied != nil {
+			applied = func() bool {
+				checked++
+				return test.applied()
+			}
+		}
+
+		err := DoNonIdempotent(testPolicy(&slept), failing(&calls, test.errs...), applied)
+		if (err != nil) != test.wantErr || calls != test.wantCalls || checked != test.wantChecked {
+			t.Errorf("%s: expected [%d] calls, [%d] checks and error [%v], got [%d], [%d] and [%v]",
+				name, test.wantCalls, test.wantChecked, test.wantErr, calls, checked, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package review
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
+)
+
+// Entry is a low-confidence match waiting for a decision. PlaylistId is the
+// first YouTube Playlist of the Source, which is split into parts when it
+// holds too many Tracks. The part an accepted video goes to is decided when
+// it is added.
+type Entry struct {
+	PlaylistId   string                 `json:"playlistId"`
+	PlaylistName string                 `json:"playlistName"`
+	Source       youtube.PlaylistSource `json:"source"`
+	Track        youtube.Track          `json:"track"`
+	Candidates   []youtube.Candidate    `json:"candidates"`
+}
+
+// Queue is the set of Entries stored in the review queue file
+type Queue struct {
+	path    string
+	Entries []Entry `json:"entries"`
+}
+
+// Load reads the review queue file at path. A missing file is an empty Queue.
+func Load(path string) (*Queue, error) {
+	queue := &Queue{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return queue, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, queue); err != nil {
+		return nil, err
+	}
+
+	return queue, nil
+}
+
+// Add queues an Entry, replacing any existing Entry for the same Track in the
+// same Playlist.
+func (q *Queue) Add(entry Entry) {
+	for idx, existing := range q.Entries {
+		if existing.PlaylistId == entry.PlaylistId && existing.Track.SpotifyId == entry.Track.SpotifyId {
+			q.Entries[idx] = entry
+			return
+		}
+	}
+
+	q.Entries = append(q.Entries, entry)
+}
+
+// Save writes the Queue back to its file
+func (q *Queue) Save() error {
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Saving matches for review", "count", len(q.Entries), "file", q.path)
+	return os.WriteFile(q.path, data, 0600)
+}
@@ -0,0 +1,161 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package review
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
+)
+
+var logger = logging.For("review")
+
+// Run walks through every Entry in the Queue, showing the Spotify Track next
+// to its Candidates, and asks for a decision:
+//
+//	1-N   accept that Candidate
+//	r     reject the match, removing it from the Queue
+//	s     skip, leaving it in the Queue for later
+//	URL   accept a YouTube video URL or ID instead of any Candidate
+//	q     stop reviewing
+//
+// Accepted videos are then given to add, once for each Playlist, and the
+// Queue is saved with only the skipped Entries remaining.
+func Run(queue *Queue, add AddFunc, in io.Reader, out io.Writer) error {
+	if len(queue.Entries) == 0 {
+		logger.Info("No matches waiting for review")
+		return nil
+	}
+
+	scanner := bufio.NewScanner(in)
+	accepted := make(map[string][]string)
+	var playlistOrder []Entry
+	var remaining []Entry
+
+	for idx, entry := range queue.Entries {
+		printEntry(out, idx+1, len(queue.Entries), entry)
+
+		videoId, decision := prompt(scanner, out, entry)
+		switch decision {
+		case decisionAccept:
+			if _, ok := accepted[entry.PlaylistId]; !ok {
+				playlistOrder = append(playlistOrder, entry)
+			}
+			accepted[entry.PlaylistId] = append(accepted[entry.PlaylistId], videoId)
+		case decisionSkip:
+			remaining = append(remaining, entry)
+		case decisionQuit:
+			remaining = append(remaining, queue.Entries[idx:]...)
+		}
+
+		if decision == decisionQuit {
+			break
+		}
+	}
+
+	for _, entry := range playlistOrder {
+		if err := add(entry, accepted[entry.PlaylistId]); err != nil {
+			return err
+		}
+	}
+
+	queue.Entries = remaining
+	return queue.Save()
+}
+
+// AddFunc adds the videos accepted for the Entries of one Playlist
+type AddFunc func(entry Entry, videoIds []string) error
+
+// AddTo returns an AddFunc which adds the videos to the Playlist named by the
+// Entry
+func AddTo(yt *youtube.YouTube) AddFunc {
+	return func(entry Entry, videoIds []string) error {
+		_, err := yt.AddToPlaylist(entry.PlaylistId, videoIds...)
+		return err
+	}
+}
+
+type decision int
+
+const (
+	decisionAccept decision = iota
+	decisionReject
+	decisionSkip
+	decisionQuit
+)
+
+func printEntry(out io.Writer, position, total int, entry Entry) {
+	fmt.Fprintf(out, "\n[%d/%d] %s - %s", position, total, entry.Track.Artist, entry.Track.Name)
+	if entry.Track.Album != "" {
+		fmt.Fprintf(out, " (%s)", entry.Track.Album)
+	}
+	fmt.Fprintf(out, " [%s]\n", formatDuration(entry.Track.Duration))
+	fmt.Fprintf(out, "Playlist: %s\n", entry.PlaylistName)
+
+	for idx, candidate := range entry.Candidates {
+		fmt.Fprintf(out, "  %d. %s\n", idx+1, candidate.Title)
+		fmt.Fprintf(out, "     Channel: %s  Duration: %s  Score: %.2f\n", candidate.Channel, candidate.Length, candidate.Score)
+		if candidate.Explanation != "" {
+			fmt.Fprintf(out, "     Scored: %s\n", candidate.Explanation)
+		}
+		fmt.Fprintf(out, "     %s\n", candidate.URL())
+	}
+}
+
+func prompt(scanner *bufio.Scanner, out io.Writer, entry Entry) (string, decision) {
+	for {
+		fmt.Fprintf(out, "Choose [1-%d], (r)eject, (s)kip, (q)uit, or paste a video URL: ", len(entry.Candidates))
+
+		if !scanner.Scan() {
+			return "", decisionQuit
+		}
+		input := strings.TrimSpace(scanner.Text())
+
+		switch strings.ToLower(input) {
+		case "r":
+			return "", decisionReject
+		case "s", "":
+			return "", decisionSkip
+		case "q":
+			return "", decisionQuit
+		}
+
+		if choice, err := strconv.Atoi(input); err == nil {
+			if choice >= 1 && choice <= len(entry.Candidates) {
+				return entry.Candidates[choice-1].VideoId, decisionAccept
+			}
+			fmt.Fprintf(out, "There is no Candidate [%d]\n", choice)
+			continue
+		}
+
+		if videoId, ok := youtube.ParseVideoId(input); ok {
+			return videoId, decisionAccept
+		}
+
+		fmt.Fprintf(out, "Did not understand [%s]\n", input)
+	}
+}
+
+func formatDuration(milliseconds int) string {
+	seconds := milliseconds / 1000
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,135 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package review
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube/fakeyoutube"
+	"google.golang.org/api/option"
+)
+
+func newEntry(playlistId, spotifyId string, videoIds ...string) Entry {
+	entry := Entry{
+		PlaylistId:   playlistId,
+		PlaylistName: "Mix",
+		Track:        youtube.Track{SpotifyId: spotifyId, Artist: "Band", Name: "Song " + spotifyId, Duration: 200000},
+	}
+	for _, videoId := range videoIds {
+		entry.Candidates = append(entry.Candidates, youtube.Candidate{VideoId: videoId, Title: "Band - Song " + spotifyId, Score: 0.4})
+	}
+	return entry
+}
+
+func TestRun(t *testing.T) {
+	server := fakeyoutube.New()
+	t.Cleanup(server.Close)
+	yt, err := youtube.NewYouTubeWithOptions(config.Default(), option.WithEndpoint(server.URL()), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("NewYouTubeWithOptions: %v", err)
+	}
+	playlistId := server.AddPlaylist("Mix")
+
+	queue, err := Load(filepath.Join(t.TempDir(), "review-queue.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	queue.Add(newEntry(playlistId, "accepted", "acceptvid01", "acceptvid02"))
+	queue.Add(newEntry(playlistId, "skipped", "skipvideo01"))
+	queue.Add(newEntry(playlistId, "picked", "pickvideo01", "pickvideo02"))
+	queue.Add(newEntry(playlistId, "pasted", "pastevideo1"))
+	queue.Add(newEntry(playlistId, "rejected", "rejectvideo"))
+
+	// An out of range choice and nonsense are asked again
+	input := strings.Join([]string{"1", "s", "9", "what", "2", "https://www.youtube.com/watch?v=othervideo1", "r"}, "\n")
+	var out strings.Builder
+	if err := Run(queue, AddTo(yt), strings.NewReader(input), &out); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := []string{"acceptvid01", "pickvideo02", "othervideo1"}
+	if got := server.PlaylistVideoIds(playlistId); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if !strings.Contains(out.String(), "There is no Candidate [9]") || !strings.Contains(out.String(), "Did not understand [what]") {
+		t.Fatalf("expected invalid choices to be reported, got:\n%s", out.String())
+	}
+
+	saved, err := Load(queue.path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(saved.Entries) != 1 || saved.Entries[0].Track.SpotifyId != "skipped" {
+		t.Fatalf("expected only the skipped Entry to remain, got %+v", saved.Entries)
+	}
+}
+
+func TestRunKeepsEntriesAfterQuit(t *testing.T) {
+	queue, err := Load(filepath.Join(t.TempDir(), "review-queue.json"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	queue.Add(newEntry("PLmix", "first", "firstvideo1"))
+	queue.Add(newEntry("PLmix", "second", "secondvideo"))
+
+	// Nothing is accepted, so the YouTube client is not used
+	if err := Run(queue, nil, strings.NewReader("s\nq\n"), &strings.Builder{}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	saved, err := Load(queue.path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(saved.Entries) != 2 {
+		t.Fatalf("expected both Entries to remain, got %+v", saved.Entries)
+	}
+}
+
+func TestQueueRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review-queue.json")
+
+	queue, err := Load(path)
+	if err != nil || len(queue.Entries) != 0 {
+		t.Fatalf("expected a missing file to be an empty Queue, got %+v %v", queue, err)
+	}
+
+	queue.Add(newEntry("PLone", "track1", "video000001", "video000002"))
+	queue.Add(newEntry("PLtwo", "track1", "video000003"))
+	// The same Track in the same Playlist replaces the earlier Entry
+	queue.Add(newEntry("PLone", "track1", "video000004"))
+	if err := queue.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(loaded.Entries) != 2 {
+		t.Fatalf("expected 2 Entries, got %+v", loaded.Entries)
+	}
+	first := loaded.Entries[0]
+	if first.PlaylistId != "PLone" || first.Track.Name != "Song track1" || len(first.Candidates) != 1 || first.Candidates[0].VideoId != "video000004" {
+		t.Fatalf("unexpected Entry: %+v", first)
+	}
+}
@@ -0,0 +1,256 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package rules filters and reorders the Tracks of a Spotify Playlist before
+// they are converted, so that no search is made for Tracks which are not
+// wanted.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// The accepted values of Rules.Explicit
+const (
+	ExplicitAny     = ""
+	ExplicitExclude = "exclude"
+	ExplicitOnly    = "only"
+)
+
+// Rules are declared in the configuration file. Filters are applied first,
+// then the transforms in the order sort, reverse, dedupe and limit. Zero
+// values apply no rule.
+type Rules struct {
+	// IncludeArtists keeps only Tracks by one of these artists
+	IncludeArtists []string `json:"includeArtists,omitempty"`
+	// ExcludeArtists drops Tracks by any of these artists
+	ExcludeArtists []string `json:"excludeArtists,omitempty"`
+	// Explicit is "exclude" to drop explicit Tracks, or "only" to keep only
+	// explicit Tracks
+	Explicit string `json:"explicit,omitempty"`
+	// AddedAfter and AddedBefore keep Tracks added to the Playlist within a
+	// range of dates, given as "2006-01-02" or RFC 3339
+	AddedAfter  string `json:"addedAfter,omitempty"`
+	AddedBefore string `json:"addedBefore,omitempty"`
+	// MinPopularity and MaxPopularity keep Tracks within a range of Spotify
+	// popularity, between 0 and 100. A MaxPopularity of zero is no upper
+	// bound, not a range of only zero.
+	MinPopularity int `json:"minPopularity,omitempty"`
+	MaxPopularity int `json:"maxPopularity,omitempty"`
+	// MinDurationSeconds and MaxDurationSeconds keep Tracks within a range of
+	// lengths. A MaxDurationSeconds of zero is no upper bound.
+	MinDurationSeconds int `json:"minDurationSeconds,omitempty"`
+	MaxDurationSeconds int `json:"maxDurationSeconds,omitempty"`
+	// IncludeAddedBy keeps only Tracks added by one of these Spotify user IDs,
+	// for collaborative Playlists
+	IncludeAddedBy []string `json:"includeAddedBy,omitempty"`
+	// ExcludeAddedBy drops Tracks added by any of these Spotify user IDs
+	ExcludeAddedBy []string `json:"excludeAddedBy,omitempty"`
+
+	// SortByAdded orders Tracks by when they were added, oldest first
+	SortByAdded bool `json:"sortByAdded,omitempty"`
+	// Reverse reverses the order of the Tracks
+	Reverse bool `json:"reverse,omitempty"`
+	// Dedupe keeps only the first of Tracks with the same Spotify ID or ISRC
+	Dedupe bool `json:"dedupe,omitempty"`
+	// Limit keeps only the first Limit Tracks. Zero keeps them all.
+	Limit int `json:"limit,omitempty"`
+}
+
+// Item is what the Rules know of a Track in a Playlist
+type Item struct {
+	SpotifyId  string
+	ISRC       string
+	Artists    []string
+	Explicit   bool
+	Popularity int
+	Duration   time.Duration
+	AddedAt    time.Time
+	AddedBy    string
+}
+
+// Validate reports the first Rule which cannot be applied
+func (r Rules) Validate() error {
+	switch r.Explicit {
+	case ExplicitAny, ExplicitExclude, ExplicitOnly:
+	default:
+		return fmt.Errorf("rules.explicit must be one of [%s, %s] or empty", ExplicitExclude, ExplicitOnly)
+	}
+
+	after, err := parseDate(r.AddedAfter)
+	if err != nil {
+		return fmt.Errorf("rules.addedAfter: %w", err)
+	}
+	before, err := parseDate(r.AddedBefore)
+	if err != nil {
+		return fmt.Errorf("rules.addedBefore: %w", err)
+	}
+	if !after.IsZero() && !before.IsZero() && !after.Before(before) {
+		return errors.New("rules.addedAfter must be before rules.addedBefore")
+	}
+
+	if r.MinPopularity < 0 || r.MaxPopularity < 0 || r.MinPopularity > 100 || r.MaxPopularity > 100 {
+		return errors.New("rules popularity must be between 0 and 100")
+	}
+	if r.MaxPopularity > 0 && r.MinPopularity > r.MaxPopularity {
+		return errors.New("rules.minPopularity must not be above rules.maxPopularity")
+	}
+	if r.MinDurationSeconds < 0 || r.MaxDurationSeconds < 0 {
+		return errors.New("rules durations must not be negative")
+	}
+	if r.MaxDurationSeconds > 0 && r.MinDurationSeconds > r.MaxDurationSeconds {
+		return errors.New("rules.minDurationSeconds must not be above rules.maxDurationSeconds")
+	}
+	if r.Limit < 0 {
+		return errors.New("rules.limit must not be negative")
+	}
+
+	return nil
+}
+
+// Apply filters and reorders items by the Rules. Describe returns what the
+// Rules need to know of each item.
+func Apply[T any](r Rules, items []T, describe func(T) Item) []T {
+	after, _ := parseDate(r.AddedAfter)
+	before, _ := parseDate(r.AddedBefore)
+
+	type described struct {
+		value T
+		item  Item
+	}
+
+	var kept []described
+	for _, value := range items {
+		item := describe(value)
+		if r.keep(item, after, before) {
+			kept = append(kept, described{value: value, item: item})
+		}
+	}
+
+	if r.SortByAdded {
+		slices.SortStableFunc(kept, func(a, b described) int {
+			return a.item.AddedAt.Compare(b.item.AddedAt)
+		})
+	}
+	if r.Reverse {
+		slices.Reverse(kept)
+	}
+	if r.Dedupe {
+		kept = Dedupe(kept, func(d described) (string, string) {
+			return d.item.SpotifyId, d.item.ISRC
+		})
+	}
+	if r.Limit > 0 && len(kept) > r.Limit {
+		kept = kept[:r.Limit]
+	}
+
+	result := make([]T, 0, len(kept))
+	for _, d := range kept {
+		result = append(result, d.value)
+	}
+	return result
+}
+
+// Dedupe keeps the first of items sharing a Spotify ID or ISRC, so that the
+// same recording released twice is only kept once. Identify returns the
+// Spotify ID and ISRC of each item.
+func Dedupe[T any](items []T, identify func(T) (spotifyId, isrc string)) []T {
+	seen := make(map[string]bool)
+
+	return slices.DeleteFunc(slices.Clone(items), func(item T) bool {
+		spotifyId, isrc := identify(item)
+		keys := []string{"id:" + spotifyId}
+		if isrc != "" {
+			keys = append(keys, "isrc:"+strings.ToUpper(isrc))
+		}
+
+		duplicate := slices.ContainsFunc(keys, func(key string) bool { return seen[key] })
+		for _, key := range keys {
+			seen[key] = true
+		}
+		return duplicate
+	})
+}
+
+// keep reports whether an item passes every filter
+func (r Rules) keep(item Item, after, before time.Time) bool {
+	if len(r.IncludeArtists) > 0 && !anyArtist(item.Artists, r.IncludeArtists) {
+		return false
+	}
+	if anyArtist(item.Artists, r.ExcludeArtists) {
+		return false
+	}
+
+	switch r.Explicit {
+	case ExplicitExclude:
+		if item.Explicit {
+			return false
+		}
+	case ExplicitOnly:
+		if !item.Explicit {
+			return false
+		}
+	}
+
+	if !after.IsZero() && item.AddedAt.Before(after) {
+		return false
+	}
+	if !before.IsZero() && !item.AddedAt.Before(before) {
+		return false
+	}
+
+	if item.Popularity < r.MinPopularity || (r.MaxPopularity > 0 && item.Popularity > r.MaxPopularity) {
+		return false
+	}
+
+	seconds := int(item.Duration.Seconds())
+	if seconds < r.MinDurationSeconds || (r.MaxDurationSeconds > 0 && seconds > r.MaxDurationSeconds) {
+		return false
+	}
+
+	if len(r.IncludeAddedBy) > 0 && !slices.Contains(r.IncludeAddedBy, item.AddedBy) {
+		return false
+	}
+	return !slices.Contains(r.ExcludeAddedBy, item.AddedBy)
+}
+
+// anyArtist reports whether any of the artists is in the list, ignoring case
+func anyArtist(artists, list []string) bool {
+	for _, artist := range artists {
+		for _, name := range list {
+			if strings.EqualFold(artist, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseDate reads a date as "2006-01-02" or RFC 3339. An empty string is the
+// zero Time.
+func parseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
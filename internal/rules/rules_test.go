@@ -0,0 +1,153 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package rules
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func date(value string) time.Time {
+	parsed, _ := parseDate(value)
+	return parsed
+}
+
+var items = []Item{
+	{SpotifyId: "alpha", ISRC: "GBAAA0000001", Artists: []string{"Band"}, Popularity: 10, Duration: 200 * time.Second, AddedAt: date("2025-03-01"), AddedBy: "owner"},
+	{SpotifyId: "beta", ISRC: "GBAAA0000002", Artists: []string{"Band", "Guest"}, Explicit: true, Popularity: 50, Duration: 240 * time.Second, AddedAt: date("2025-01-01"), AddedBy: "friend"},
+	{SpotifyId: "gamma", Artists: []string{"Other"}, Popularity: 90, Duration: 600 * time.Second, AddedAt: date("2025-02-01"), AddedBy: "owner"},
+	{SpotifyId: "delta", ISRC: "gbaaa0000001", Artists: []string{"Band"}, Popularity: 0, Duration: 180 * time.Second, AddedAt: date("2025-04-01"), AddedBy: "owner"},
+	{SpotifyId: "beta", ISRC: "GBAAA0000002", Artists: []string{"Band", "Guest"}, Explicit: true, Popularity: 50, Duration: 240 * time.Second, AddedAt: date("2025-05-01"), AddedBy: "owner"},
+}
+
+func TestApply(t *testing.T) {
+	tests := map[string]struct {
+		rules Rules
+		want  []string
+	}{
+		"no rules":              {Rules{}, []string{"alpha", "beta", "gamma", "delta", "beta"}},
+		"include artists":       {Rules{IncludeArtists: []string{"guest"}}, []string{"beta", "beta"}},
+		"exclude artists":       {Rules{ExcludeArtists: []string{"BAND"}}, []string{"gamma"}},
+		"include and exclude":   {Rules{IncludeArtists: []string{"Band"}, ExcludeArtists: []string{"Guest"}}, []string{"alpha", "delta"}},
+		"exclude explicit":      {Rules{Explicit: ExplicitExclude}, []string{"alpha", "gamma", "delta"}},
+		"only explicit":         {Rules{Explicit: ExplicitOnly}, []string{"beta", "beta"}},
+		"added after":           {Rules{AddedAfter: "2025-02-01"}, []string{"alpha", "gamma", "delta", "beta"}},
+		"added before":          {Rules{AddedBefore: "2025-03-01"}, []string{"beta", "gamma"}},
+		"added within":          {Rules{AddedAfter: "2025-02-01", AddedBefore: "2025-04-01T00:00:00Z"}, []string{"alpha", "gamma"}},
+		"min popularity":        {Rules{MinPopularity: 50}, []string{"beta", "gamma", "beta"}},
+		"max popularity":        {Rules{MaxPopularity: 10}, []string{"alpha", "delta"}},
+		"popularity range":      {Rules{MinPopularity: 10, MaxPopularity: 50}, []string{"alpha", "beta", "beta"}},
+		"min duration":          {Rules{MinDurationSeconds: 240}, []string{"beta", "gamma", "beta"}},
+		"max duration":          {Rules{MaxDurationSeconds: 200}, []string{"alpha", "delta"}},
+		"duration range":        {Rules{MinDurationSeconds: 200, MaxDurationSeconds: 300}, []string{"alpha", "beta", "beta"}},
+		"include added by":      {Rules{IncludeAddedBy: []string{"friend"}}, []string{"beta"}},
+		"exclude added by":      {Rules{ExcludeAddedBy: []string{"owner"}}, []string{"beta"}},
+		"sort by added":         {Rules{SortByAdded: true}, []string{"beta", "gamma", "alpha", "delta", "beta"}},
+		"reverse":               {Rules{Reverse: true}, []string{"beta", "delta", "gamma", "beta", "alpha"}},
+		"dedupe by id and isrc": {Rules{Dedupe: true}, []string{"alpha", "beta", "gamma"}},
+		"limit":                 {Rules{Limit: 2}, []string{"alpha", "beta"}},
+		"limit above length":    {Rules{Limit: 10}, []string{"alpha", "beta", "gamma", "delta", "beta"}},
+		// Sorted, reversed and deduped, the newest copy of each Track is kept
+		"transforms in order": {Rules{SortByAdded: true, Reverse: true, Dedupe: true, Limit: 2}, []string{"beta", "delta"}},
+		"filters then limit":  {Rules{Explicit: ExplicitExclude, Limit: 1}, []string{"alpha"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.rules.Validate(); err != nil {
+				t.Fatalf("Validate: %v", err)
+			}
+
+			var got []string
+			for _, item := range Apply(test.rules, items, func(item Item) Item { return item }) {
+				got = append(got, item.SpotifyId)
+			}
+			if !slices.Equal(got, test.want) {
+				t.Fatalf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyLeavesItemsUnchanged(t *testing.T) {
+	original := slices.Clone(items)
+	Apply(Rules{SortByAdded: true, Reverse: true, Dedupe: true}, items, func(item Item) Item { return item })
+
+	for idx := range items {
+		if items[idx].SpotifyId != original[idx].SpotifyId {
+			t.Fatalf("expected the items to keep their order, got %v", items)
+		}
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	type track struct{ id, isrc string }
+	tracks := []track{
+		{"alpha", "GBAAA0000001"},
+		{"alpharerelease", "gbaaa0000001"},
+		{"beta", ""},
+		{"beta", ""},
+		{"gamma", ""},
+	}
+
+	got := Dedupe(tracks, func(t track) (string, string) { return t.id, t.isrc })
+	want := []track{{"alpha", "GBAAA0000001"}, {"beta", ""}, {"gamma", ""}}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if len(tracks) != 5 || tracks[1].id != "alpharerelease" {
+		t.Fatalf("expected the input to be unchanged, got %v", tracks)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		rules Rules
+		err   string
+	}{
+		"empty":                {Rules{}, ""},
+		"every rule":           {Rules{Explicit: ExplicitOnly, AddedAfter: "2025-01-01", AddedBefore: "2025-02-01T00:00:00Z", MinPopularity: 10, MaxPopularity: 90, MinDurationSeconds: 60, MaxDurationSeconds: 600, Limit: 5}, ""},
+		"no upper bounds":      {Rules{MinPopularity: 50, MinDurationSeconds: 300}, ""},
+		"unknown explicit":     {Rules{Explicit: "maybe"}, "rules.explicit"},
+		"bad added after":      {Rules{AddedAfter: "yesterday"}, "rules.addedAfter"},
+		"bad added before":     {Rules{AddedBefore: "2025-13-01"}, "rules.addedBefore"},
+		"empty date range":     {Rules{AddedAfter: "2025-02-01", AddedBefore: "2025-02-01"}, "rules.addedAfter must be before"},
+		"negative popularity":  {Rules{MinPopularity: -1}, "popularity"},
+		"popularity above 100": {Rules{MaxPopularity: 101}, "popularity"},
+		"inverted popularity":  {Rules{MinPopularity: 60, MaxPopularity: 40}, "rules.minPopularity"},
+		"negative duration":    {Rules{MaxDurationSeconds: -1}, "durations"},
+		"inverted duration":    {Rules{MinDurationSeconds: 300, MaxDurationSeconds: 200}, "rules.minDurationSeconds"},
+		"negative limit":       {Rules{Limit: -1}, "rules.limit"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.rules.Validate()
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got [%v]", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected an error containing [%s], got [%v]", test.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/report"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
+	"github.com/zmb3/spotify/v2"
+)
+
+// maxTracksPerRequest is the most Tracks Spotify returns from a single
+// GetTracks call
+const maxTracksPerRequest = 50
+
+func (s *Spotify) GetAlbum(albumId spotify.ID) *spotify.FullAlbum {
+	album, err := s.client.GetAlbum(context.Background(), albumId)
+	if err != nil {
+		logging.Fatal(logger, "Error retrieving album", "albumId", albumId, "error", err)
+	}
+
+	return album
+}
+
+// GetAlbumTracks returns every Track on an Album in order, following
+// pagination. Album listings leave out the ISRC, so the full Tracks are looked
+// up in batches. Tracks which are unavailable are nil, so that each Track
+// keeps its position on the Album.
+func (s *Spotify) GetAlbumTracks(albumId spotify.ID) []*spotify.FullTrack {
+	ctx := context.Background()
+
+	page, err := s.client.GetAlbumTracks(ctx, albumId, spotify.Limit(maxTracksPerRequest))
+	if err != nil {
+		logging.Fatal(logger, "Error retrieving album", "albumId", albumId, "error", err)
+	}
+
+	var ids []spotify.ID
+	for {
+		for _, track := range page.Tracks {
+			ids = append(ids, track.ID)
+		}
+
+		err := s.client.NextPage(ctx, page)
+		if errors.Is(err, spotify.ErrNoMorePages) {
+			break
+		}
+		if err != nil {
+			logging.Fatal(logger, "Error retrieving album", "albumId", albumId, "error", err)
+		}
+	}
+
+	var tracks []*spotify.FullTrack
+	for start := 0; start < len(ids); start += maxTracksPerRequest {
+		batch, err := s.client.GetTracks(ctx, ids[start:min(start+maxTracksPerRequest, len(ids))])
+		if err != nil {
+			logging.Fatal(logger, "Error retrieving album Tracks", "albumId", albumId, "error", err)
+		}
+
+		for _, track := range batch {
+			if track != nil && len(track.Artists) == 0 {
+				track = nil
+			}
+			tracks = append(tracks, track)
+		}
+	}
+
+	return tracks
+}
+
+// AddAlbumToYouTube converts a Spotify Album according to the AlbumMode. The
+// Album is looked up on YouTube Music, and if every song of the album found
+// matches the Track at the same position, its official playlist is either
+// linked or copied into a new Playlist. Tracks the album does not cover are
+// searched for one at a time.
+func (s *Spotify) AddAlbumToYouTube(albumId spotify.ID, yt *youtube.YouTube) {
+	spAlbum := s.GetAlbum(albumId)
+	album := youtube.Album{SpotifyId: albumId.String(), Name: spAlbum.Name}
+	if len(spAlbum.Artists) > 0 {
+		album.Artist = spAlbum.Artists[0].Name
+	}
+	spTracks := s.GetAlbumTracks(albumId)
+	album.Songs = len(spTracks)
+	for position, spTrack := range spTracks {
+		if spTrack == nil {
+			logger.Info("Skipping unavailable item", "album", spAlbum.Name, "position", position+1)
+			continue
+		}
+		track := toYouTubeTrack(*spTrack)
+		track.Position = position + 1
+		album.Tracks = append(album.Tracks, track)
+	}
+
+	ytPlayListName := fmt.Sprintf("%s - %s", album.Artist, album.Name)
+	logger.Info("Converting Album to YouTube", "name", ytPlayListName, "albumId", albumId, "mode", s.config.AlbumMode)
+
+	var albumMatch *youtube.AlbumMatch
+	if s.config.AlbumMode != config.AlbumTracks {
+		var err error
+		if albumMatch, err = yt.FindAlbum(album); err != nil {
+			logger.Warn("Album lookup failed. Searching for each Track.", "album", album.Query(), "error", err)
+		}
+	}
+
+	if s.config.AlbumMode == config.AlbumLink {
+		if albumMatch.Verified() {
+			s.linkAlbum(ytPlayListName, albumMatch, yt)
+			return
+		}
+		logger.Info("Album could not be verified. Copying it instead.", "album", album.Query())
+	}
+
+	source := youtube.PlaylistSource{
+		SpotifyId: albumId.String(),
+		Name:      ytPlayListName,
+		Owner:     album.Artist,
+		URL:       spAlbum.ExternalURLs["spotify"],
+		ImageURL:  largestImage(spAlbum.Images),
+	}
+
+	s.addTracksToYouTube(source, album.Tracks, yt, func(idx int, track youtube.Track) (*youtube.Match, error) {
+		if match := albumMatch.Match(idx); match != nil {
+			return match, nil
+		}
+		return yt.FindTrack(track, 5)
+	})
+}
+
+// linkAlbum reports every Track of an Album as covered by the official album
+// playlist, without creating a Playlist
+func (s *Spotify) linkAlbum(name string, albumMatch *youtube.AlbumMatch, yt *youtube.YouTube) {
+	logger.Info("Linking official album playlist", "name", name, "url", albumMatch.URL())
+
+	playlistReport := s.report.StartPlaylist(name, albumMatch.Album.SpotifyId, yt.Credits)
+	defer func() { playlistReport.Finish(yt.Credits) }()
+	playlistReport.YouTubeId = albumMatch.PlaylistId
+
+	for _, match := range albumMatch.Matches {
+		best := match.Best()
+		playlistReport.Add(&report.Track{
+			SpotifyId:  match.Track.SpotifyId,
+			Artist:     match.Track.Artist,
+			Name:       match.Track.Name,
+			VideoId:    best.VideoId,
+			VideoTitle: best.Title,
+			Score:      best.Score,
+			Method:     string(match.Method),
+			Outcome:    report.Linked,
+		})
+	}
+}
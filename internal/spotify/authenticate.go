@@ -20,10 +20,10 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
-	"log"
 	"net/http"
 	"strings"
 
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
 	"github.com/google/uuid"
 	"github.com/pkg/browser"
 	"github.com/zmb3/spotify/v2/auth"
@@ -55,24 +55,24 @@ var (
 
 func getSpotifyClient() *spotify.Client {
 	if clientId == "" || clientSecret == "" {
-		log.Fatal("SPOTIFY_ID or SPOTIFY_SECRET are blank. This binary was compiled incorrectly.")
+		logging.Fatal(logger, "SPOTIFY_ID or SPOTIFY_SECRET are blank. This binary was compiled incorrectly.")
 	}
 
 	http.HandleFunc("/callback", completeAuth)
 
 	go func() {
-		log.Println("Starting HTTP server")
+		logger.Debug("Starting HTTP server", "addr", ":8000")
 		err := http.ListenAndServe(":8000", nil)
 		if err != nil {
-			log.Fatal(err)
+			logging.Fatal(logger, "HTTP server failed", "error", err)
 		}
 	}()
 
 	url := auth.AuthURL(state)
-	log.Println("Please log in to Spotify by visiting the following page in your browser:", url)
+	logger.Info("Please log in to Spotify by visiting the following page in your browser", "url", url)
 
 	if err := browser.OpenURL(url); err != nil {
-		log.Printf("Could not open browser automatically. Please visit the URL above to log in: Error: [%v]", err)
+		logger.Warn("Could not open browser automatically. Please visit the URL above to log in", "error", err)
 	}
 
 	// wait for auth to complete
@@ -84,24 +84,24 @@ func getSpotifyClient() *spotify.Client {
 func getSpotifyPrivateUser(ctx context.Context, client spotify.Client) *spotify.PrivateUser {
 	user, err := client.CurrentUser(ctx)
 	if err != nil {
-		log.Fatal(err)
+		logging.Fatal(logger, "Error retrieving Spotify user", "error", err)
 	}
 
 	return user
 }
 
 func completeAuth(w http.ResponseWriter, r *http.Request) {
-	log.Println("Got request for:", r.URL.String())
+	logger.Debug("Got request", "url", logging.RedactURL(r.URL))
 
 	tok, err := auth.Token(r.Context(), state, r)
 	if err != nil {
 		http.Error(w, "Couldn't get token", http.StatusForbidden)
-		log.Fatal(err)
+		logging.Fatal(logger, "Couldn't get token", "error", err)
 	}
 
 	if st := r.FormValue("state"); st != state {
 		http.NotFound(w, r)
-		log.Fatalf("State mismatch: %s != %s\n", st, state)
+		logging.Fatal(logger, "State mismatch", "got", st, "expected", state)
 	}
 
 	client := spotify.New(auth.Client(r.Context(), tok))
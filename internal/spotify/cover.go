@@ -0,0 +1,83 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package spotify
+
+import (
+	"bytes"
+
+	syncstate "github.com/Renegade-Master/spotify-playlist-converter/internal/state"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
+	"github.com/zmb3/spotify/v2"
+)
+
+// recordLink remembers which YouTube Playlist the source was converted to,
+// so that it is found again after either is renamed, and copies its cover
+// when CopyCoverArt is enabled
+func (s *Spotify) recordLink(links *syncstate.State, link *syncstate.Link, source youtube.PlaylistSource, ytPlaylistId string, yt *youtube.YouTube) {
+	if link.YouTubeId != ytPlaylistId {
+		// A cover copied to another Playlist does not count
+		link.YouTubeId = ytPlaylistId
+		link.CoverURL = ""
+	}
+
+	if s.config.CopyCoverArt {
+		s.copyCover(source, link, yt)
+	}
+
+	if err := links.Save(); err != nil {
+		logger.Error("Error saving sync state", "error", err)
+	}
+}
+
+// copyCover uploads the cover of the source to its YouTube Playlist, unless
+// the same cover was copied before. Spotify gives each version of a cover its
+// own URL, so an unchanged URL is an unchanged image.
+func (s *Spotify) copyCover(source youtube.PlaylistSource, link *syncstate.Link, yt *youtube.YouTube) {
+	if source.ImageURL == "" {
+		return
+	}
+	if source.ImageURL == link.CoverURL {
+		logger.Debug("Cover has not changed since it was copied", "name", source.Name)
+		return
+	}
+
+	var image bytes.Buffer
+	if err := (spotify.Image{URL: source.ImageURL}).Download(&image); err != nil {
+		logger.Warn("Error downloading Spotify cover", "name", source.Name, "url", source.ImageURL, "error", err)
+		return
+	}
+
+	if err := yt.SetPlaylistImage(link.YouTubeId, image.Bytes()); err != nil {
+		logger.Warn("Error copying cover to YouTube Playlist", "name", source.Name, "playlistId", link.YouTubeId, "error", err)
+		return
+	}
+	link.CoverURL = source.ImageURL
+}
+
+// largestImage returns the URL of the largest image, or an empty string if
+// there are none
+func largestImage(images []spotify.Image) string {
+	url := ""
+	var largest spotify.Numeric
+	for _, image := range images {
+		if url == "" || image.Width > largest {
+			url = image.URL
+			largest = image.Width
+		}
+	}
+	return url
+}
@@ -0,0 +1,510 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package fakespotify is an in-memory fake of the Spotify Web API endpoints
+// used by the converter, for testing without a browser login.
+package fakespotify
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const defaultPageSize = 20
+
+// Server is a fake Spotify Web API. Create one with New, and point a
+// spotify.Client at it with spotify.WithBaseURL(server.URL()).
+type Server struct {
+	server *httptest.Server
+
+	mu        sync.Mutex
+	userId    string
+	playlists []*Playlist
+	albums    []*Album
+
+	// PageSize caps the number of items in every page, regardless of the limit
+	// requested, to exercise pagination with small fixtures. Zero uses the
+	// requested limit.
+	PageSize int
+}
+
+// Playlist is a fixture Playlist owned by the fake user
+type Playlist struct {
+	ID          string
+	Name        string
+	Description string
+	// Image is the cover of the Playlist, served as a JPEG. Nil means the
+	// Playlist has no cover.
+	Image []byte
+	Items []Item
+}
+
+// Album is a fixture Spotify Album
+type Album struct {
+	ID      string
+	Name    string
+	Artists []string
+	Tracks  []Track
+}
+
+// Item is a fixture entry in a Playlist. Exactly one of Track or Episode is
+// set, unless the content is unavailable.
+type Item struct {
+	AddedAt string
+	AddedBy string
+	IsLocal bool
+	Track   *Track
+	Episode *Episode
+}
+
+// Track is a fixture Spotify Track
+type Track struct {
+	ID         string
+	Name       string
+	Artists    []string
+	Album      string
+	DurationMs int
+	ISRC       string
+	Explicit   bool
+	Popularity int
+	// Unavailable makes looking the Track up by ID return null, as for
+	// content which is not available in the market
+	Unavailable bool
+}
+
+// Episode is a fixture podcast Episode
+type Episode struct {
+	ID   string
+	Name string
+}
+
+// New starts a fake Server for a user with no Playlists
+func New() *Server {
+	s := &Server{userId: "fakeuser"}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// URL is the base URL to pass to spotify.WithBaseURL
+func (s *Server) URL() string {
+	return s.server.URL + "/"
+}
+
+// Close shuts the Server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// UserID returns the ID of the fake user
+func (s *Server) UserID() string {
+	return s.userId
+}
+
+// AddPlaylist creates an empty Playlist. Items may be added to the returned
+// Playlist until the first request is made.
+func (s *Server) AddPlaylist(name string) *Playlist {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	playlist := &Playlist{ID: fmt.Sprintf("fakeplaylist%04d", len(s.playlists)+1), Name: name}
+	s.playlists = append(s.playlists, playlist)
+	return playlist
+}
+
+// AddAlbum creates an Album with no Tracks. Tracks may be added to the
+// returned Album until the first request is made.
+func (s *Server) AddAlbum(name string, artists ...string) *Album {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	album := &Album{ID: fmt.Sprintf("fakealbum%04d", len(s.albums)+1), Name: name, Artists: artists}
+	s.albums = append(s.albums, album)
+	return album
+}
+
+// AddTrack appends a Track to the Album, which becomes the Track's Album
+func (a *Album) AddTrack(track Track) *Album {
+	track.Album = a.Name
+	a.Tracks = append(a.Tracks, track)
+	return a
+}
+
+// AddTrack appends a Track to the Playlist
+func (p *Playlist) AddTrack(track Track) *Playlist {
+	p.Items = append(p.Items, Item{AddedAt: "2025-01-01T00:00:00Z", AddedBy: "fakeuser", Track: &track})
+	return p
+}
+
+// AddLocalFile appends a local file, which has no Spotify ID
+func (p *Playlist) AddLocalFile(name, artist string) *Playlist {
+	p.Items = append(p.Items, Item{
+		AddedAt: "2025-01-01T00:00:00Z",
+		AddedBy: "fakeuser",
+		IsLocal: true,
+		Track:   &Track{Name: name, Artists: []string{artist}},
+	})
+	return p
+}
+
+// AddEpisode appends a podcast Episode
+func (p *Playlist) AddEpisode(id, name string) *Playlist {
+	p.Items = append(p.Items, Item{AddedAt: "2025-01-01T00:00:00Z", AddedBy: "fakeuser", Episode: &Episode{ID: id, Name: name}})
+	return p
+}
+
+// AddUnavailable appends an entry whose content is not available in the market
+func (p *Playlist) AddUnavailable() *Playlist {
+	p.Items = append(p.Items, Item{AddedAt: "2025-01-01T00:00:00Z", AddedBy: "fakeuser"})
+	return p
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(parts) == 1 && parts[0] == "me":
+		writeJSON(w, s.user())
+	case len(parts) == 3 && parts[0] == "users" && parts[2] == "playlists":
+		if parts[1] != s.userId {
+			writeError(w, http.StatusNotFound, "No such user")
+			return
+		}
+		s.listPlaylists(w, r)
+	case len(parts) == 2 && parts[0] == "playlists":
+		s.getPlaylist(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "playlists" && parts[2] == "tracks":
+		s.listItems(w, r, parts[1])
+	case len(parts) == 2 && parts[0] == "albums":
+		s.getAlbum(w, r, parts[1])
+	case len(parts) == 3 && parts[0] == "albums" && parts[2] == "tracks":
+		s.listAlbumTracks(w, r, parts[1])
+	case len(parts) == 1 && parts[0] == "tracks":
+		s.getTracks(w, r)
+	case len(parts) == 2 && parts[0] == "images":
+		s.getImage(w, parts[1])
+	default:
+		writeError(w, http.StatusNotFound, "Service not found")
+	}
+}
+
+func (s *Server) user() map[string]interface{} {
+	return map[string]interface{}{
+		"id":           s.userId,
+		"display_name": "Fake User",
+		"uri":          "spotify:user:" + s.userId,
+		"href":         s.URL() + "users/" + s.userId,
+		"type":         "user",
+	}
+}
+
+func (s *Server) listPlaylists(w http.ResponseWriter, r *http.Request) {
+	var playlists []interface{}
+	for _, playlist := range s.playlists {
+		playlists = append(playlists, s.simplePlaylist(playlist))
+	}
+
+	writeJSON(w, s.page(r, playlists))
+}
+
+func (s *Server) getPlaylist(w http.ResponseWriter, r *http.Request, playlistId string) {
+	playlist := s.find(playlistId)
+	if playlist == nil {
+		writeError(w, http.StatusNotFound, "Invalid playlist Id")
+		return
+	}
+
+	full := s.simplePlaylist(playlist)
+	tracksRequest := r.Clone(r.Context())
+	tracksRequest.URL.Path = "/playlists/" + playlistId + "/tracks"
+	tracksRequest.URL.RawQuery = "limit=100"
+	full["tracks"] = s.page(tracksRequest, s.items(playlist))
+	full["followers"] = map[string]interface{}{"total": 0}
+
+	writeJSON(w, full)
+}
+
+func (s *Server) listItems(w http.ResponseWriter, r *http.Request, playlistId string) {
+	playlist := s.find(playlistId)
+	if playlist == nil {
+		writeError(w, http.StatusNotFound, "Invalid playlist Id")
+		return
+	}
+
+	writeJSON(w, s.page(r, s.items(playlist)))
+}
+
+func (s *Server) getAlbum(w http.ResponseWriter, r *http.Request, albumId string) {
+	album := s.findAlbum(albumId)
+	if album == nil {
+		writeError(w, http.StatusNotFound, "Invalid album Id")
+		return
+	}
+
+	artists := make([]interface{}, 0, len(album.Artists))
+	for idx, artist := range album.Artists {
+		artists = append(artists, map[string]interface{}{"id": fmt.Sprintf("fakeartist%s%d", album.ID, idx), "name": artist})
+	}
+
+	tracksRequest := r.Clone(r.Context())
+	tracksRequest.URL.Path = "/albums/" + albumId + "/tracks"
+	tracksRequest.URL.RawQuery = "limit=50"
+
+	writeJSON(w, map[string]interface{}{
+		"id":            album.ID,
+		"name":          album.Name,
+		"album_type":    "album",
+		"artists":       artists,
+		"uri":           "spotify:album:" + album.ID,
+		"href":          s.URL() + "albums/" + album.ID,
+		"external_urls": map[string]string{"spotify": "https://open.spotify.com/album/" + album.ID},
+		"total_tracks":  len(album.Tracks),
+		"images":        []interface{}{},
+		"tracks":        s.page(tracksRequest, albumTracks(album)),
+	})
+}
+
+func (s *Server) listAlbumTracks(w http.ResponseWriter, r *http.Request, albumId string) {
+	album := s.findAlbum(albumId)
+	if album == nil {
+		writeError(w, http.StatusNotFound, "Invalid album Id")
+		return
+	}
+
+	writeJSON(w, s.page(r, albumTracks(album)))
+}
+
+// getTracks looks Tracks up by ID in every Playlist and Album, returning null
+// for IDs which are not found
+func (s *Server) getTracks(w http.ResponseWriter, r *http.Request) {
+	known := make(map[string]*Track)
+	for _, playlist := range s.playlists {
+		for _, item := range playlist.Items {
+			if item.Track != nil && !item.IsLocal {
+				known[item.Track.ID] = item.Track
+			}
+		}
+	}
+	for _, album := range s.albums {
+		for idx := range album.Tracks {
+			known[album.Tracks[idx].ID] = &album.Tracks[idx]
+		}
+	}
+
+	var tracks []interface{}
+	for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if track, ok := known[id]; ok && !track.Unavailable {
+			tracks = append(tracks, trackJSON(track, false))
+		} else {
+			tracks = append(tracks, nil)
+		}
+	}
+
+	writeJSON(w, map[string]interface{}{"tracks": tracks})
+}
+
+func albumTracks(album *Album) []interface{} {
+	tracks := make([]interface{}, 0, len(album.Tracks))
+	for idx := range album.Tracks {
+		tracks = append(tracks, trackJSON(&album.Tracks[idx], false))
+	}
+	return tracks
+}
+
+func (s *Server) simplePlaylist(playlist *Playlist) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            playlist.ID,
+		"name":          playlist.Name,
+		"description":   playlist.Description,
+		"collaborative": false,
+		"public":        false,
+		"snapshot_id":   "snapshot",
+		"uri":           "spotify:playlist:" + playlist.ID,
+		"href":          s.URL() + "playlists/" + playlist.ID,
+		"external_urls": map[string]string{"spotify": "https://open.spotify.com/playlist/" + playlist.ID},
+		"owner":         s.user(),
+		"images":        s.images(playlist),
+		"tracks": map[string]interface{}{
+			"href":  s.URL() + "playlists/" + playlist.ID + "/tracks",
+			"total": len(playlist.Items),
+		},
+	}
+}
+
+// images describes the cover of a Playlist. Its URL changes with the image.
+func (s *Server) images(playlist *Playlist) []interface{} {
+	if playlist.Image == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{map[string]interface{}{
+		"url":    s.URL() + "images/" + imageId(playlist.Image),
+		"height": 640,
+		"width":  640,
+	}}
+}
+
+func (s *Server) getImage(w http.ResponseWriter, id string) {
+	for _, playlist := range s.playlists {
+		if playlist.Image != nil && imageId(playlist.Image) == id {
+			w.Header().Set("Content-Type", "image/jpeg")
+			_, _ = w.Write(playlist.Image)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "Image not found")
+}
+
+func imageId(image []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(image))[:16]
+}
+
+func (s *Server) items(playlist *Playlist) []interface{} {
+	items := make([]interface{}, 0, len(playlist.Items))
+	for _, item := range playlist.Items {
+		var track interface{}
+		switch {
+		case item.Episode != nil:
+			track = map[string]interface{}{
+				"type": "episode",
+				"id":   item.Episode.ID,
+				"name": item.Episode.Name,
+				"uri":  "spotify:episode:" + item.Episode.ID,
+			}
+		case item.Track != nil:
+			track = trackJSON(item.Track, item.IsLocal)
+		}
+
+		items = append(items, map[string]interface{}{
+			"added_at": item.AddedAt,
+			"added_by": map[string]interface{}{"id": item.AddedBy, "type": "user"},
+			"is_local": item.IsLocal,
+			"track":    track,
+		})
+	}
+	return items
+}
+
+func trackJSON(track *Track, isLocal bool) map[string]interface{} {
+	artists := make([]interface{}, 0, len(track.Artists))
+	for idx, artist := range track.Artists {
+		artists = append(artists, map[string]interface{}{
+			"id":   fmt.Sprintf("fakeartist%s%d", track.ID, idx),
+			"name": artist,
+		})
+	}
+
+	result := map[string]interface{}{
+		"type":        "track",
+		"name":        track.Name,
+		"artists":     artists,
+		"album":       map[string]interface{}{"name": track.Album},
+		"duration_ms": track.DurationMs,
+		"explicit":    track.Explicit,
+		"popularity":  track.Popularity,
+		"is_local":    isLocal,
+	}
+
+	if !isLocal {
+		result["id"] = track.ID
+		result["uri"] = "spotify:track:" + track.ID
+		result["external_ids"] = map[string]string{"isrc": track.ISRC}
+	}
+
+	return result
+}
+
+// page slices items according to the limit and offset of the request, in the
+// shape of a Spotify paging object.
+func (s *Server) page(r *http.Request, items []interface{}) map[string]interface{} {
+	query := r.URL.Query()
+
+	limit := defaultPageSize
+	if requested, err := strconv.Atoi(query.Get("limit")); err == nil && requested > 0 {
+		limit = requested
+	}
+	if s.PageSize > 0 && s.PageSize < limit {
+		limit = s.PageSize
+	}
+
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	offset = min(max(offset, 0), len(items))
+	end := min(offset+limit, len(items))
+
+	pageURL := func(offset int) string {
+		return fmt.Sprintf("%s%s?limit=%d&offset=%d", s.URL(), strings.TrimPrefix(r.URL.Path, "/"), limit, offset)
+	}
+
+	var next interface{}
+	if end < len(items) {
+		next = pageURL(end)
+	}
+
+	if items == nil {
+		items = []interface{}{}
+	}
+
+	return map[string]interface{}{
+		"href":     pageURL(offset),
+		"items":    items[offset:end],
+		"limit":    limit,
+		"offset":   offset,
+		"total":    len(items),
+		"next":     next,
+		"previous": nil,
+	}
+}
+
+func (s *Server) find(playlistId string) *Playlist {
+	for _, playlist := range s.playlists {
+		if playlist.ID == playlistId {
+			return playlist
+		}
+	}
+	return nil
+}
+
+func (s *Server) findAlbum(albumId string) *Album {
+	for _, album := range s.albums {
+		if album.ID == albumId {
+			return album
+		}
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"status": status, "message": message},
+	})
+}
@@ -0,0 +1,211 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package spotify
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/rand/v2"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/rules"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
+	"github.com/zmb3/spotify/v2"
+)
+
+// MergeOrder is the order Tracks from several Playlists are merged in
+type MergeOrder string
+
+const (
+	// MergeBySource keeps every Track of the first Playlist, then the second,
+	// and so on
+	MergeBySource MergeOrder = "source"
+	// MergeInterleave takes one Track from each Playlist in turn
+	MergeInterleave MergeOrder = "interleave"
+	// MergeByAddedAt orders Tracks by when they were added to their Playlist
+	MergeByAddedAt MergeOrder = "added-at"
+	// MergeShuffle shuffles the Tracks, in the same order for the same seed
+	MergeShuffle MergeOrder = "shuffle"
+)
+
+// MergeOrders lists every supported MergeOrder
+var MergeOrders = []MergeOrder{MergeBySource, MergeInterleave, MergeByAddedAt, MergeShuffle}
+
+// ParseMergeOrder returns the MergeOrder with the given name
+func ParseMergeOrder(name string) (MergeOrder, error) {
+	for _, order := range MergeOrders {
+		if string(order) == name {
+			return order, nil
+		}
+	}
+	return "", fmt.Errorf("unknown merge order [%s], expected one of %v", name, MergeOrders)
+}
+
+// MergeOptions describe the YouTube Playlist that Spotify Playlists are
+// merged into
+type MergeOptions struct {
+	// Name is the name of the merged Playlist
+	Name  string
+	Order MergeOrder
+	// Seed makes MergeShuffle repeatable
+	Seed uint64
+}
+
+// mergeItem is a Track of one of the Playlists being merged
+type mergeItem struct {
+	track   youtube.Track
+	addedAt string
+	source  int
+}
+
+// FindPlaylists returns the Playlists whose names match a shell pattern such
+// as "Workout *", ignoring case. A malformed pattern returns
+// path.ErrBadPattern.
+func (s *Spotify) FindPlaylists(pattern string) ([]spotify.SimplePlaylist, error) {
+	pattern = strings.ToLower(pattern)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+
+	var matches []spotify.SimplePlaylist
+	for _, playlist := range s.GetPlaylists() {
+		ok, err := path.Match(pattern, strings.ToLower(playlist.Name))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matches = append(matches, playlist)
+		}
+	}
+	return matches, nil
+}
+
+// MergePlaylistsToYouTube converts several Spotify Playlists into a single
+// YouTube Playlist. Playlists which cannot be retrieved are left out. The
+// configured rules are applied to each Playlist, then
+// Tracks are ordered by the MergeOrder, and a Track is only added once even if
+// it is in several Playlists, whether by Spotify ID, by ISRC, or by being
+// matched to the same video.
+func (s *Spotify) MergePlaylistsToYouTube(playlistIds []spotify.ID, options MergeOptions, yt *youtube.YouTube) {
+	var names []string
+	var sources [][]mergeItem
+	for _, playlistId := range playlistIds {
+		playlist := s.GetPlaylist(playlistId)
+		if playlist == nil {
+			logger.Error("Error retrieving playlist. Leaving it out of the merge.", "playlistId", playlistId)
+			continue
+		}
+		name := playlist.Name
+		names = append(names, name)
+
+		playlistItems := s.GetPlaylistItems(playlistId)
+		filtered := rules.Apply(s.config.Rules, playlistItems, describeItem)
+		if len(filtered) != len(playlistItems) {
+			logger.Info("Rules removed Tracks from the Playlist", "name", name, "kept", len(filtered), "removed", len(playlistItems)-len(filtered))
+		}
+
+		var items []mergeItem
+		for _, item := range filtered {
+			items = append(items, mergeItem{track: toYouTubeTrack(*item.Track.Track), addedAt: item.AddedAt, source: len(sources)})
+		}
+		sources = append(sources, items)
+	}
+
+	if len(sources) == 0 {
+		logger.Error("None of the Playlists could be retrieved", "name", options.Name)
+		return
+	}
+
+	logger.Info("Merging Playlists to YouTube", "name", options.Name, "playlists", names, "order", options.Order)
+
+	var tracks []youtube.Track
+	for _, item := range dedupeMerged(orderMerged(sources, options)) {
+		tracks = append(tracks, item.track)
+	}
+
+	source := youtube.PlaylistSource{
+		SpotifyId:   mergeId(options.Name),
+		Name:        options.Name,
+		Description: "Merged from " + strings.Join(names, ", "),
+		Owner:       s.privateClient.DisplayName,
+	}
+
+	s.addTracksToYouTube(source, tracks, yt, func(_ int, track youtube.Track) (*youtube.Match, error) {
+		return yt.FindTrack(track, 5)
+	})
+}
+
+// orderMerged combines the Tracks of each source into one list in the
+// MergeOrder
+func orderMerged(sources [][]mergeItem, options MergeOptions) []mergeItem {
+	var merged []mergeItem
+
+	switch options.Order {
+	case MergeInterleave:
+		for idx := 0; ; idx++ {
+			added := false
+			for _, items := range sources {
+				if idx < len(items) {
+					merged = append(merged, items[idx])
+					added = true
+				}
+			}
+			if !added {
+				break
+			}
+		}
+	default:
+		for _, items := range sources {
+			merged = append(merged, items...)
+		}
+	}
+
+	switch options.Order {
+	case MergeByAddedAt:
+		// Spotify dates are RFC 3339 in UTC, so they sort as strings
+		slices.SortStableFunc(merged, func(a, b mergeItem) int {
+			return strings.Compare(a.addedAt, b.addedAt)
+		})
+	case MergeShuffle:
+		random := rand.New(rand.NewPCG(options.Seed, options.Seed))
+		random.Shuffle(len(merged), func(i, j int) {
+			merged[i], merged[j] = merged[j], merged[i]
+		})
+	}
+
+	return merged
+}
+
+// dedupeMerged keeps the first of each Track, comparing Spotify IDs and ISRCs
+// so that the same recording released twice is only searched for once
+func dedupeMerged(items []mergeItem) []mergeItem {
+	unique := rules.Dedupe(items, func(item mergeItem) (string, string) {
+		return item.track.SpotifyId, item.track.ISRC
+	})
+	if len(unique) != len(items) {
+		logger.Debug("Tracks are in several merged Playlists. Adding them once.", "removed", len(items)-len(unique))
+	}
+	return unique
+}
+
+// mergeId identifies a merged Playlist by its name, as it has no Spotify ID
+// of its own
+func mergeId(name string) string {
+	return fmt.Sprintf("merge%x", sha256.Sum256([]byte(name)))[:21]
+}
@@ -0,0 +1,185 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package spotify
+
+import (
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/review"
+	syncstate "github.com/Renegade-Master/spotify-playlist-converter/internal/state"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
+	youtubeapi "google.golang.org/api/youtube/v3"
+)
+
+// playlistPart is one of the YouTube Playlists a source is converted to
+type playlistPart struct {
+	source youtube.PlaylistSource
+	id     string
+	items  []*youtubeapi.PlaylistItem
+	// size is how many videos the part holds, including those assigned to it
+	// but not yet added
+	size int
+}
+
+// splitPlaylist is the YouTube Playlists a source is converted to. A source
+// holding more Tracks than fit in one Playlist is split into parts. Tracks
+// stay in the part they were first added to, so that the split is stable
+// across runs, and new Tracks are added to the last part until it is full.
+type splitPlaylist struct {
+	parts []*playlistPart
+	limit int
+	links *syncstate.State
+}
+
+// openSplitPlaylist finds or creates the first part of the source, and finds
+// the later parts made by earlier runs
+func (s *Spotify) openSplitPlaylist(source youtube.PlaylistSource, yt *youtube.YouTube) *splitPlaylist {
+	links, err := syncstate.Load(s.config.StateFile)
+	if err != nil {
+		logging.Fatal(logger, "Error loading sync state", "error", err)
+	}
+
+	split := &splitPlaylist{limit: s.config.PlaylistItemLimit, links: links}
+	split.parts = append(split.parts, s.openPart(split, source, true, yt))
+
+	for part := 2; ; part++ {
+		source.Part = part
+		// A later part can only exist if an earlier run made it, or the part
+		// before it is full
+		if _, linked := links.Links[source.Key()]; !linked && split.last().size < split.limit {
+			break
+		}
+
+		next := s.openPart(split, source, false, yt)
+		if next == nil {
+			break
+		}
+		split.parts = append(split.parts, next)
+	}
+
+	return split
+}
+
+// openPart finds the YouTube Playlist of a part, creating it if create is
+// set, and reads the items it already holds. It returns nil if the part does
+// not exist and was not created.
+func (s *Spotify) openPart(split *splitPlaylist, source youtube.PlaylistSource, create bool, yt *youtube.YouTube) *playlistPart {
+	link := split.links.Link(source.Key())
+
+	var ytPlaylistId string
+	isNew := false
+	if create {
+		ytPlaylistId, isNew = yt.CreatePlaylist(source, link.YouTubeId)
+	} else if ytPlaylistId = yt.FindPlaylist(source, link.YouTubeId); ytPlaylistId == "" {
+		delete(split.links.Links, source.Key())
+		return nil
+	}
+
+	s.recordLink(split.links, link, source, ytPlaylistId, yt)
+
+	part := &playlistPart{source: source, id: ytPlaylistId}
+	if !isNew {
+		part.items = yt.GetPlaylistItems(ytPlaylistId)
+		part.size = len(part.items)
+	}
+	return part
+}
+
+// items returns the items of every part
+func (split *splitPlaylist) items() []*youtubeapi.PlaylistItem {
+	var items []*youtubeapi.PlaylistItem
+	for _, part := range split.parts {
+		items = append(items, part.items...)
+	}
+	return items
+}
+
+func (split *splitPlaylist) first() *playlistPart {
+	return split.parts[0]
+}
+
+func (split *splitPlaylist) last() *playlistPart {
+	return split.parts[len(split.parts)-1]
+}
+
+// partSlice is a run of videos to add to one part
+type partSlice struct {
+	part       *playlistPart
+	start, end int
+}
+
+// assign shares count new videos between the parts in order, filling the last
+// part and then creating new parts as needed
+func (s *Spotify) assign(split *splitPlaylist, count int, yt *youtube.YouTube) []partSlice {
+	var runs []partSlice
+
+	for start := 0; start < count; {
+		part := split.last()
+		room := split.limit - part.size
+		if room <= 0 {
+			source := part.source
+			source.Part = max(source.Part, 1) + 1
+			logger.Info("Playlist is full. Starting a new part.", "name", yt.PlaylistTitle(part.source), "part", source.Part)
+
+			split.parts = append(split.parts, s.openPart(split, source, true, yt))
+			continue
+		}
+
+		end := min(start+room, count)
+		runs = append(runs, partSlice{part: part, start: start, end: end})
+
+		part.size += end - start
+		start = end
+	}
+
+	return runs
+}
+
+// ReviewAdder returns the review.AddFunc which adds accepted videos to the
+// parts of their source as a run would, filling the last part and starting
+// new parts as needed. Videos already in any part are not added again. An
+// Entry queued without its source is added to the Playlist it names.
+func ReviewAdder(cfg *config.Config, yt *youtube.YouTube) review.AddFunc {
+	s := &Spotify{config: cfg}
+
+	return func(entry review.Entry, videoIds []string) error {
+		if entry.Source.SpotifyId == "" {
+			return review.AddTo(yt)(entry, videoIds)
+		}
+
+		split := s.openSplitPlaylist(entry.Source, yt)
+		existing := make(map[string]bool)
+		for _, item := range split.items() {
+			existing[item.Snippet.ResourceId.VideoId] = true
+		}
+
+		var missing []string
+		for _, videoId := range videoIds {
+			if !existing[videoId] {
+				existing[videoId] = true
+				missing = append(missing, videoId)
+			}
+		}
+
+		for _, run := range s.assign(split, len(missing), yt) {
+			if _, err := yt.AddToPlaylist(run.part.id, missing[run.start:run.end]...); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
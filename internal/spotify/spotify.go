@@ -18,23 +18,44 @@ package spotify
 
 import (
 	"context"
-	"fmt"
-	"log"
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/report"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/review"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/rules"
 	"github.com/Renegade-Master/spotify-playlist-converter/internal/util"
 	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
 	"github.com/zmb3/spotify/v2"
+	youtubeapi "google.golang.org/api/youtube/v3"
 )
 
+var logger = logging.For("spotify")
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
 type Spotify struct {
 	client        *spotify.Client
 	privateClient *spotify.PrivateUser
+	config        *config.Config
+	report        *report.Report
+}
+
+func NewSpotify(cfg *config.Config) *Spotify {
+	return NewSpotifyWithClient(cfg, createSpotifyService())
 }
 
-func NewSpotify() *Spotify {
-	spotifyClient := createSpotifyService()
+// NewSpotifyWithClient creates a Spotify from an already authorised client,
+// skipping the browser login. It is used to point the converter at another
+// endpoint with spotify.WithBaseURL.
+func NewSpotifyWithClient(cfg *config.Config, spotifyClient *spotify.Client) *Spotify {
 	spotifyPrivateUser := getSpotifyPrivateUser(context.Background(), *spotifyClient)
-	return &Spotify{client: spotifyClient, privateClient: spotifyPrivateUser}
+	return &Spotify{client: spotifyClient, privateClient: spotifyPrivateUser, config: cfg, report: report.New()}
 }
 
 func createSpotifyService() *spotify.Client {
@@ -42,29 +63,50 @@ func createSpotifyService() *spotify.Client {
 }
 
 func (s *Spotify) ListInfo() {
-	log.Printf("User ID: [%s]", s.privateClient.ID)
-	log.Printf("Display name: [%s]", s.privateClient.DisplayName)
-	log.Printf("Spotify URI: [%s]", string(s.privateClient.URI))
-	log.Printf("Endpoint: [%s]", s.privateClient.Endpoint)
+	logger.Info("Spotify user",
+		"id", s.privateClient.ID,
+		"displayName", s.privateClient.DisplayName,
+		"uri", string(s.privateClient.URI),
+		"endpoint", s.privateClient.Endpoint,
+	)
 }
 
 func (s *Spotify) ListPlaylists() {
-	playlists, _ := s.client.GetPlaylistsForUser(context.Background(), s.privateClient.ID)
+	playlists := s.GetPlaylists()
 
-	log.Printf("Found [%d] playlists", len(playlists.Playlists))
-	for idx, playlist := range playlists.Playlists {
-		log.Printf("%d. %s\n", idx+1, playlist.Name)
-		log.Printf("   Description: %s\n", playlist.Description)
-		log.Printf("   ID: %s\n", playlist.ID)
-		log.Printf("   URI: %s\n", playlist.URI)
-		log.Println()
+	logger.Info("Found playlists", "count", len(playlists))
+	for idx, playlist := range playlists {
+		logger.Info("Playlist",
+			"index", idx+1,
+			"name", playlist.Name,
+			"description", playlist.Description,
+			"id", playlist.ID,
+			"uri", playlist.URI,
+		)
 	}
 }
 
 func (s *Spotify) GetPlaylists() []spotify.SimplePlaylist {
-	playlists, _ := s.client.GetPlaylistsForUser(context.Background(), s.privateClient.ID)
+	ctx := context.Background()
+
+	page, err := s.client.GetPlaylistsForUser(ctx, s.privateClient.ID, spotify.Limit(50))
+	if err != nil {
+		logging.Fatal(logger, "Error retrieving playlists", "error", err)
+	}
+
+	playlists := page.Playlists
+	for {
+		err := s.client.NextPage(ctx, page)
+		if errors.Is(err, spotify.ErrNoMorePages) {
+			break
+		}
+		if err != nil {
+			logging.Fatal(logger, "Error retrieving playlists", "error", err)
+		}
+		playlists = append(playlists, page.Playlists...)
+	}
 
-	return playlists.Playlists
+	return playlists
 }
 
 func (s *Spotify) GetPlaylist(playlistId spotify.ID) *spotify.FullPlaylist {
@@ -73,80 +115,325 @@ func (s *Spotify) GetPlaylist(playlistId spotify.ID) *spotify.FullPlaylist {
 	return playlist
 }
 
-func (s *Spotify) ListPlaylist(playlistId spotify.ID) {
-	playlist, err := s.client.GetPlaylistItems(context.Background(), playlistId)
+// GetPlaylistItems returns every Track in a Playlist, following pagination.
+// Local files, podcast Episodes, and Tracks unavailable in the user's market
+// cannot be found on YouTube, so are left out.
+func (s *Spotify) GetPlaylistItems(playlistId spotify.ID) []spotify.PlaylistItem {
+	ctx := context.Background()
+
+	page, err := s.client.GetPlaylistItems(ctx, playlistId, spotify.Limit(100))
 	if err != nil {
-		log.Fatalf("Error retrieving playlist: [%s]", err)
+		logging.Fatal(logger, "Error retrieving playlist", "playlistId", playlistId, "error", err)
 	}
 
-	log.Printf("Found [%d] Tracks", len(playlist.Items))
-	for idx, track := range playlist.Items {
-		log.Printf("%d. %s\n", idx+1, track.Track.Track.Name)
-		log.Printf("   Album: %s\n", track.Track.Track.Album.Name)
-		log.Printf("   Artists: %s\n", track.Track.Track.Artists)
-		log.Printf("   Duration: %d\n", track.Track.Track.Duration)
-		log.Printf("   ID: %s\n", track.Track.Track.ID)
-		log.Printf("   URI: %s\n", track.Track.Track.URI)
-		log.Println()
+	var items []spotify.PlaylistItem
+	for {
+		for _, item := range page.Items {
+			switch {
+			case item.IsLocal:
+				logger.Info("Skipping local file", "name", item.Track.Track.Name)
+			case item.Track.Episode != nil:
+				logger.Info("Skipping podcast episode", "name", item.Track.Episode.Name)
+			case item.Track.Track == nil || len(item.Track.Track.Artists) == 0:
+				logger.Info("Skipping unavailable item")
+			default:
+				items = append(items, item)
+			}
+		}
+
+		err := s.client.NextPage(ctx, page)
+		if errors.Is(err, spotify.ErrNoMorePages) {
+			break
+		}
+		if err != nil {
+			logging.Fatal(logger, "Error retrieving playlist", "playlistId", playlistId, "error", err)
+		}
+	}
+
+	return items
+}
+
+func (s *Spotify) ListPlaylist(playlistId spotify.ID) {
+	items := s.GetPlaylistItems(playlistId)
+
+	logger.Info("Found Tracks", "count", len(items))
+	for idx, track := range items {
+		logger.Info("Track",
+			"index", idx+1,
+			"name", track.Track.Track.Name,
+			"album", track.Track.Track.Album.Name,
+			"artists", track.Track.Track.Artists,
+			"duration", track.Track.Track.Duration,
+			"id", track.Track.Track.ID,
+			"uri", track.Track.Track.URI,
+		)
 	}
 }
 
 func (s *Spotify) AddPlaylistToYouTube(playlistId spotify.ID, yt *youtube.YouTube) {
-	ytPlayListName := s.GetPlaylist(playlistId).Name
-	log.Printf("Converting Playlist [%s] to YouTube...", ytPlayListName)
-
-	spotifyPlaylist := s.GetPlaylist(playlistId)
-
-	ytPlaylistId, isNewPlaylist := yt.CreatePlaylist(ytPlayListName)
-	if !isNewPlaylist {
-		ytPlaylistItems := yt.GetPlaylistItems(ytPlaylistId)
-		var duplicateTrackIds []int
-
-		// Get the Title for each Spotify and YouTube Tracks
-		for idx, spPlaylistItem := range spotifyPlaylist.Tracks.Tracks {
-			for _, ytPlaylistItem := range ytPlaylistItems {
-				spotifyTitle := fmt.Sprintf("%s %s", spPlaylistItem.Track.Artists[0].Name, spPlaylistItem.Track.Name)
-				youTubeTitle := ytPlaylistItem.Snippet.Title
-
-				// Attempt to determine if this Track already exists in the YouTube Playlist
-				distance := util.LevenshteinDistance(spotifyTitle, youTubeTitle)
-				if distance <= util.MaxDistance {
-					log.Printf("It is likely that [%s] and [%s] are the same Track. Not adding.", spotifyTitle, youTubeTitle)
-					duplicateTrackIds = append(duplicateTrackIds, idx)
-					continue
-				}
-			}
+	spPlaylist := s.GetPlaylist(playlistId)
+	logger.Info("Converting Playlist to YouTube", "name", spPlaylist.Name, "playlistId", playlistId)
+
+	source := youtube.PlaylistSource{
+		SpotifyId:   playlistId.String(),
+		Name:        spPlaylist.Name,
+		Description: plainText(spPlaylist.Description),
+		Owner:       spPlaylist.Owner.DisplayName,
+		URL:         spPlaylist.ExternalURLs["spotify"],
+		ImageURL:    largestImage(spPlaylist.Images),
+	}
+
+	items := s.GetPlaylistItems(playlistId)
+	filtered := rules.Apply(s.config.Rules, items, describeItem)
+	if len(filtered) != len(items) {
+		logger.Info("Rules removed Tracks from the Playlist", "name", spPlaylist.Name, "kept", len(filtered), "removed", len(items)-len(filtered))
+	}
+
+	var tracks []youtube.Track
+	for _, spPlaylistItem := range filtered {
+		tracks = append(tracks, toYouTubeTrack(*spPlaylistItem.Track.Track))
+	}
+
+	s.addTracksToYouTube(source, tracks, yt, func(_ int, track youtube.Track) (*youtube.Match, error) {
+		return yt.FindTrack(track, 5)
+	})
+}
+
+// describeItem returns what the configured rules need to know of a Playlist
+// item
+func describeItem(item spotify.PlaylistItem) rules.Item {
+	track := item.Track.Track
+	artists := make([]string, 0, len(track.Artists))
+	for _, artist := range track.Artists {
+		artists = append(artists, artist.Name)
+	}
+
+	addedAt, _ := time.Parse(time.RFC3339, item.AddedAt)
+	return rules.Item{
+		SpotifyId:  track.ID.String(),
+		ISRC:       track.ExternalIDs["isrc"],
+		Artists:    artists,
+		Explicit:   track.Explicit,
+		Popularity: int(track.Popularity),
+		Duration:   track.TimeDuration(),
+		AddedAt:    addedAt,
+		AddedBy:    item.AddedBy.ID,
+	}
+}
+
+// trackFinder finds the YouTube video for the Track at a position of a
+// Playlist or Album
+type trackFinder func(idx int, track youtube.Track) (*youtube.Match, error)
+
+// addTracksToYouTube adds the videos found for each Track to the YouTube
+// Playlist converted from the source, creating it if needed, and splitting it
+// into parts once it is full. Tracks already in any part are skipped, and
+// low-confidence matches are queued for review.
+func (s *Spotify) addTracksToYouTube(source youtube.PlaylistSource, tracks []youtube.Track, yt *youtube.YouTube, find trackFinder) {
+	ytPlayListName := yt.PlaylistTitle(source)
+	playlistReport := s.report.StartPlaylist(ytPlayListName, source.SpotifyId, yt.Credits)
+	defer func() { playlistReport.Finish(yt.Credits) }()
+
+	split := s.openSplitPlaylist(source, yt)
+	playlistReport.YouTubeId = split.first().id
+	existing := s.newExistingItems(split)
+	defer func() {
+		if err := split.links.Save(); err != nil {
+			logger.Error("Error saving sync state", "error", err)
 		}
+	}()
 
-		// Remove Tracks that already exist
-		if len(duplicateTrackIds) == len(spotifyPlaylist.Tracks.Tracks) {
-			log.Printf("All Tracks are already present in the Playlist")
-			return
+	reviewQueue, err := review.Load(s.config.ReviewQueueFile)
+	if err != nil {
+		logging.Fatal(logger, "Error loading review queue", "error", err)
+	}
+
+	var tracksToAdd []string
+	var pendingReports []*report.Track
+	for idx, track := range tracks {
+		trackReport := playlistReport.Add(&report.Track{SpotifyId: track.SpotifyId, Artist: track.Artist, Name: track.Name})
+
+		// Attempt to determine if this Track already exists in the YouTube Playlist
+		if item := existing.find(track); item != nil {
+			logger.Debug("Track is likely already in the Playlist. Not adding.", "spotify", track.Query(), "youtube", item.Snippet.Title)
+			trackReport.VideoId = item.Snippet.ResourceId.VideoId
+			trackReport.VideoTitle = item.Snippet.Title
+			trackReport.Outcome = report.Duplicate
+			existing.record(track.SpotifyId, trackReport.VideoId)
+			continue
+		}
+
+		match, err := find(idx, track)
+		if err != nil {
+			logger.Error("Error retrieving track", "track", track.Query(), "error", err)
+			trackReport.Outcome = report.Failed
+			trackReport.Error = err.Error()
+			continue
+		}
+
+		trackReport.Method = string(match.Method)
+
+		best := match.Best()
+		if best == nil {
+			logger.Warn("No YouTube results", "track", match.Track.Query())
+			trackReport.Outcome = report.NotFound
+			continue
 		}
 
-		itemsRemoved := 0
-		log.Printf("Removing [%d] Tracks that already exist in Playlist [%s]\n", len(duplicateTrackIds), playlistId)
-		for _, idx := range duplicateTrackIds {
-			spotifyPlaylist.Tracks.Tracks = util.RemoveIndexTrack(spotifyPlaylist.Tracks.Tracks, idx-itemsRemoved)
-			itemsRemoved++
+		trackReport.VideoId = best.VideoId
+		trackReport.VideoTitle = best.Title
+		trackReport.Score = best.Score
+
+		if existing.videos[best.VideoId] {
+			logger.Debug("Video is already in the Playlist. Not adding.", "track", match.Track.Query(), "videoId", best.VideoId)
+			trackReport.Outcome = report.Duplicate
+			existing.record(track.SpotifyId, best.VideoId)
+			continue
+		}
+
+		if best.Score < s.config.ReviewThreshold {
+			logger.Info("Low confidence match. Queueing for review.", "track", match.Track.Query(), "title", best.Title, "score", best.Score)
+			reviewQueue.Add(review.Entry{
+				PlaylistId:   split.first().id,
+				PlaylistName: ytPlayListName,
+				Source:       source,
+				Track:        match.Track,
+				Candidates:   match.Top(s.config.ReviewCandidates),
+			})
+			trackReport.Outcome = report.Review
+			continue
 		}
+
+		existing.videos[best.VideoId] = true
+		tracksToAdd = append(tracksToAdd, best.VideoId)
+		pendingReports = append(pendingReports, trackReport)
 	}
 
-	var tracksToAdd []string
-	for _, track := range spotifyPlaylist.Tracks.Tracks {
-		searchQuery := fmt.Sprintf("%s %s", track.Track.Artists[0].Name, track.Track.Name)
-		ytTrack := yt.GetTrackUnofficial(searchQuery, 5)
-		tracksToAdd = append(tracksToAdd, ytTrack)
+	if len(reviewQueue.Entries) > 0 {
+		if err := reviewQueue.Save(); err != nil {
+			logger.Error("Error saving review queue", "error", err)
+		}
 	}
 
-	yt.AddToPlaylist(ytPlaylistId, tracksToAdd...)
+	if len(tracksToAdd) == 0 {
+		logger.Info("No new Tracks to add to the Playlist", "name", ytPlayListName)
+		return
+	}
+
+	for _, run := range s.assign(split, len(tracksToAdd), yt) {
+		results, err := yt.AddToPlaylist(run.part.id, tracksToAdd[run.start:run.end]...)
+		if err != nil {
+			logger.Error("Error adding Tracks to Playlist", "name", yt.PlaylistTitle(run.part.source), "error", err)
+		}
+
+		for idx, result := range results {
+			trackReport := pendingReports[run.start+idx]
+			switch {
+			case result.Err != nil:
+				trackReport.Outcome = report.Failed
+				trackReport.Error = result.Err.Error()
+			case result.Duplicate:
+				trackReport.Outcome = report.Duplicate
+			default:
+				trackReport.Outcome = report.Inserted
+			}
+			if result.Err == nil {
+				existing.record(trackReport.SpotifyId, trackReport.VideoId)
+			}
+		}
+	}
+}
+
+// existingItems finds the Tracks already in the parts of a split Playlist.
+// Their titles are normalized once, rather than once for each Track.
+type existingItems struct {
+	items  []*youtubeapi.PlaylistItem
+	titles []util.Title
+	// byVideo holds the items by their video ID
+	byVideo map[string]*youtubeapi.PlaylistItem
+	// videos holds the videos in any part, so that a Track is not added to a
+	// second part
+	videos map[string]bool
+	// recorded is the video each Spotify Track was added as by earlier runs
+	recorded  map[string]string
+	matcher   util.Matcher
+	threshold float64
+}
+
+func (s *Spotify) newExistingItems(split *splitPlaylist) *existingItems {
+	link := split.links.Link(split.first().source.Key())
+	if link.Videos == nil {
+		link.Videos = make(map[string]string)
+	}
+
+	existing := &existingItems{
+		items:     split.items(),
+		byVideo:   make(map[string]*youtubeapi.PlaylistItem),
+		videos:    make(map[string]bool),
+		recorded:  link.Videos,
+		matcher:   s.config.Matcher(),
+		threshold: s.config.DuplicateThreshold,
+	}
+	for _, item := range existing.items {
+		existing.titles = append(existing.titles, existing.matcher.Normalize(item.Snippet.Title))
+		existing.byVideo[item.Snippet.ResourceId.VideoId] = item
+		existing.videos[item.Snippet.ResourceId.VideoId] = true
+	}
+	return existing
+}
+
+// find returns the item which is likely to be the same as the Track, or nil if
+// there is none. The video recorded for the Track is looked for first, and
+// then the titles are compared.
+func (e *existingItems) find(track youtube.Track) *youtubeapi.PlaylistItem {
+	if item, ok := e.byVideo[e.recorded[track.SpotifyId]]; ok {
+		return item
+	}
+
+	queries := e.matcher.TrackQueries(track.Artist, track.Name)
+	for idx, item := range e.items {
+		if e.matcher.ScoreBest(queries, e.titles[idx]) >= e.threshold {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// record remembers the video a Track is in the Playlist as
+func (e *existingItems) record(spotifyId, videoId string) {
+	if spotifyId != "" && videoId != "" {
+		e.recorded[spotifyId] = videoId
+	}
+}
+
+// plainText removes the links and HTML escapes from a Spotify description
+func plainText(description string) string {
+	return strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(description, "")))
+}
+
+// toYouTubeTrack describes a Spotify Track for searching on YouTube
+func toYouTubeTrack(track spotify.FullTrack) youtube.Track {
+	return youtube.Track{
+		SpotifyId: track.ID.String(),
+		ISRC:      track.ExternalIDs["isrc"],
+		Artist:    track.Artists[0].Name,
+		Name:      track.Name,
+		Album:     track.Album.Name,
+		Duration:  int(track.Duration),
+	}
 }
 
 func (s *Spotify) AddAllPlaylists(yt *youtube.YouTube) {
-	log.Println("Converting all playlists to YouTube...")
+	logger.Info("Converting all playlists to YouTube")
 
 	playlists := s.GetPlaylists()
 	for _, playlist := range playlists {
 		s.AddPlaylistToYouTube(playlist.ID, yt)
 	}
 }
+
+// Report returns the results of every Playlist converted so far
+func (s *Spotify) Report() *report.Report {
+	return s.report
+}
@@ -0,0 +1,828 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package spotify
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"maps"
+	"net/http"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/report"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/review"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/rules"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/spotify/fakespotify"
+	syncstate "github.com/Renegade-Master/spotify-playlist-converter/internal/state"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube/fakeyoutube"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube/innertube"
+	"github.com/zmb3/spotify/v2"
+	"google.golang.org/api/option"
+)
+
+// fakeSearch answers InnerTube searches from a fixed set of videos, returning
+// every video whose title contains the Track name.
+type fakeSearch struct {
+	videos []innertube.Video
+}
+
+func (f *fakeSearch) Dispatch(_ string, _ map[string]string, body map[string]interface{}) (map[string]interface{}, error) {
+	query := strings.ToLower(*body["query"].(*string))
+
+	var contents []interface{}
+	for _, video := range f.videos {
+		words := strings.Fields(strings.ToLower(video.Title))
+		if !slices.ContainsFunc(words, func(word string) bool { return strings.Contains(query, word) && len(word) > 3 }) {
+			continue
+		}
+
+		contents = append(contents, map[string]interface{}{
+			"videoRenderer": map[string]interface{}{
+				"videoId":    video.VideoId,
+				"title":      map[string]interface{}{"runs": []interface{}{map[string]interface{}{"text": video.Title}}},
+				"ownerText":  map[string]interface{}{"runs": []interface{}{map[string]interface{}{"text": video.Channel}}},
+				"lengthText": map[string]interface{}{"simpleText": video.Length},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"contents": map[string]interface{}{
+			"twoColumnSearchResultsRenderer": map[string]interface{}{
+				"primaryContents": map[string]interface{}{
+					"sectionListRenderer": map[string]interface{}{
+						"contents": []interface{}{
+							map[string]interface{}{"itemSectionRenderer": map[string]interface{}{"contents": contents}},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// fakeMusic answers YouTube Music searches by ISRC from a fixed set of songs,
+// and album searches by query from a fixed set of albums
+type fakeMusic struct {
+	songs  map[string]innertube.Song
+	albums map[string]innertube.AlbumPage
+}
+
+func (f *fakeMusic) Dispatch(endpoint string, _ map[string]string, body map[string]interface{}) (map[string]interface{}, error) {
+	if endpoint == "BROWSE" {
+		return f.browse(*body["browseId"].(*string)), nil
+	}
+
+	query := *body["query"].(*string)
+
+	var contents []interface{}
+	if album, ok := f.albums[query]; ok && *body["params"].(*string) == innertube.MusicAlbumsParams {
+		contents = append(contents, map[string]interface{}{
+			"musicResponsiveListItemRenderer": map[string]interface{}{
+				"flexColumns": []interface{}{
+					musicColumn(map[string]interface{}{"text": album.Title}),
+					musicColumn(map[string]interface{}{"text": "Album"}, map[string]interface{}{"text": " • "}, map[string]interface{}{"text": album.Artist}),
+				},
+				"navigationEndpoint": map[string]interface{}{"browseEndpoint": map[string]interface{}{"browseId": "MPREb_" + album.PlaylistId}},
+			},
+		})
+	}
+	if song, ok := f.songs[query]; ok {
+		contents = append(contents, musicSong(song))
+	}
+
+	return map[string]interface{}{
+		"contents": map[string]interface{}{
+			"tabbedSearchResultsRenderer": map[string]interface{}{
+				"tabs": []interface{}{map[string]interface{}{
+					"tabRenderer": map[string]interface{}{
+						"content": map[string]interface{}{
+							"sectionListRenderer": map[string]interface{}{
+								"contents": []interface{}{
+									map[string]interface{}{"musicShelfRenderer": map[string]interface{}{"contents": contents}},
+								},
+							},
+						},
+					},
+				}},
+			},
+		},
+	}, nil
+}
+
+func (f *fakeMusic) browse(browseId string) map[string]interface{} {
+	var album innertube.AlbumPage
+	for _, candidate := range f.albums {
+		if "MPREb_"+candidate.PlaylistId == browseId {
+			album = candidate
+		}
+	}
+
+	var contents []interface{}
+	for _, song := range album.Tracks {
+		contents = append(contents, musicSong(song))
+	}
+
+	return map[string]interface{}{
+		"header": map[string]interface{}{
+			"musicDetailHeaderRenderer": map[string]interface{}{
+				"title":    map[string]interface{}{"runs": []interface{}{map[string]interface{}{"text": album.Title}}},
+				"subtitle": map[string]interface{}{"runs": []interface{}{map[string]interface{}{"text": "Album"}, map[string]interface{}{"text": " • "}, map[string]interface{}{"text": album.Artist}}},
+			},
+		},
+		"contents": map[string]interface{}{
+			"singleColumnBrowseResultsRenderer": map[string]interface{}{
+				"tabs": []interface{}{map[string]interface{}{
+					"tabRenderer": map[string]interface{}{
+						"content": map[string]interface{}{
+							"sectionListRenderer": map[string]interface{}{
+								"contents": []interface{}{
+									map[string]interface{}{"musicShelfRenderer": map[string]interface{}{"contents": contents}},
+								},
+							},
+						},
+					},
+				}},
+			},
+		},
+		"microformat": map[string]interface{}{
+			"microformatDataRenderer": map[string]interface{}{"urlCanonical": "https://music.youtube.com/playlist?list=" + album.PlaylistId},
+		},
+	}
+}
+
+func musicColumn(runs ...interface{}) interface{} {
+	return map[string]interface{}{"musicResponsiveListItemFlexColumnRenderer": map[string]interface{}{"text": map[string]interface{}{"runs": runs}}}
+}
+
+func musicSong(song innertube.Song) interface{} {
+	return map[string]interface{}{
+		"musicResponsiveListItemRenderer": map[string]interface{}{
+			"playlistItemData": map[string]interface{}{"videoId": song.VideoId},
+			"flexColumns": []interface{}{
+				musicColumn(map[string]interface{}{"text": song.Title}),
+				musicColumn(map[string]interface{}{"text": song.Channel}, map[string]interface{}{"text": " • "}, map[string]interface{}{"text": song.Length}),
+			},
+		},
+	}
+}
+
+type harness struct {
+	spotify *Spotify
+	youtube *youtube.YouTube
+	sp      *fakespotify.Server
+	yt      *fakeyoutube.Server
+	music   *fakeMusic
+}
+
+func newHarness(t *testing.T, videos ...innertube.Video) *harness {
+	t.Helper()
+
+	sp := fakespotify.New()
+	t.Cleanup(sp.Close)
+	yt := fakeyoutube.New()
+	t.Cleanup(yt.Close)
+
+	cfg := config.Default()
+	dir := t.TempDir()
+	cfg.ReviewQueueFile = filepath.Join(dir, "review-queue.json")
+	cfg.StateFile = filepath.Join(dir, "sync-state.json")
+
+	ytClient, err := youtube.NewYouTubeWithOptions(cfg, option.WithEndpoint(yt.URL()), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("NewYouTubeWithOptions: %v", err)
+	}
+	ytClient.SetInnerTube(&innertube.InnerTube{Adaptor: &fakeSearch{videos: videos}})
+	music := &fakeMusic{songs: make(map[string]innertube.Song), albums: make(map[string]innertube.AlbumPage)}
+	ytClient.SetInnerTubeMusic(&innertube.InnerTube{Adaptor: music})
+
+	spClient := NewSpotifyWithClient(cfg, spotify.New(http.DefaultClient, spotify.WithBaseURL(sp.URL())))
+
+	return &harness{spotify: spClient, youtube: ytClient, sp: sp, yt: yt, music: music}
+}
+
+var (
+	trackAlpha = fakespotify.Track{ID: "alpha", Name: "Alpha Wave", Artists: []string{"Band"}, ISRC: "GBAAA0000001", DurationMs: 200000}
+	trackBeta  = fakespotify.Track{ID: "beta", Name: "Beta Blues", Artists: []string{"Band"}, ISRC: "GBAAA0000002", DurationMs: 210000}
+	trackGamma = fakespotify.Track{ID: "gamma", Name: "Gamma Groove", Artists: []string{"Band"}, ISRC: "GBAAA0000003", DurationMs: 220000}
+
+	videos = []innertube.Video{
+		{VideoId: "alphavideo1", Title: "Band - Alpha Wave", Channel: "Band", Length: "3:20"},
+		{VideoId: "betavideo01", Title: "Band - Beta Blues", Channel: "Band", Length: "3:30"},
+		{VideoId: "gammavideo1", Title: "Band - Gamma Groove", Channel: "Band", Length: "3:40"},
+	}
+)
+
+func TestGetPlaylistItemsPaginatesAndSkipsUnsupported(t *testing.T) {
+	h := newHarness(t)
+	h.sp.PageSize = 2
+
+	playlist := h.sp.AddPlaylist("Mixed").
+		AddTrack(trackAlpha).
+		AddLocalFile("Demo", "Me").
+		AddTrack(trackBeta).
+		AddEpisode("episode1", "Podcast").
+		AddUnavailable().
+		AddTrack(trackGamma)
+
+	items := h.spotify.GetPlaylistItems(spotify.ID(playlist.ID))
+
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item.Track.Track.ID.String())
+	}
+	if want := []string{"alpha", "beta", "gamma"}; !slices.Equal(ids, want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+}
+
+func TestGetPlaylistsPaginates(t *testing.T) {
+	h := newHarness(t)
+	h.sp.PageSize = 3
+
+	for range 7 {
+		h.sp.AddPlaylist("Playlist")
+	}
+
+	if playlists := h.spotify.GetPlaylists(); len(playlists) != 7 {
+		t.Fatalf("expected 7 Playlists, got [%d]", len(playlists))
+	}
+}
+
+func TestAddPlaylistToYouTube(t *testing.T) {
+	h := newHarness(t, videos...)
+
+	playlist := h.sp.AddPlaylist("Road Trip").AddTrack(trackAlpha).AddTrack(trackBeta).AddTrack(trackGamma)
+
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != 1 || ytPlaylists[0].Snippet.Title != "Road Trip" {
+		t.Fatalf("unexpected YouTube Playlists: %+v", ytPlaylists)
+	}
+
+	got := h.yt.PlaylistVideoIds(ytPlaylists[0].Id)
+	if want := []string{"alphavideo1", "betavideo01", "gammavideo1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	totals := h.spotify.Report().Totals()
+	if totals[report.Inserted] != 3 {
+		t.Fatalf("expected 3 inserted, got %v", totals)
+	}
+}
+
+func TestAddPlaylistToYouTubeCopiesCover(t *testing.T) {
+	h := newHarness(t, videos...)
+
+	playlist := h.sp.AddPlaylist("Road Trip").AddTrack(trackAlpha)
+	playlist.Image = testCover(t, color.RGBA{R: 200, A: 255})
+
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	ytPlaylistId := h.yt.Playlists()[0].Id
+	cover, err := jpeg.Decode(bytes.NewReader(h.yt.PlaylistImage(ytPlaylistId)))
+	if err != nil {
+		t.Fatalf("expected a JPEG cover: %v", err)
+	}
+	if size := cover.Bounds().Size(); size.X != 2048 || size.Y != 1152 {
+		t.Fatalf("expected a 2048x1152 cover, got %v", size)
+	}
+
+	// An unchanged cover is not uploaded again
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+	if calls := h.yt.Calls("playlistImages.insert"); calls != 1 {
+		t.Fatalf("expected 1 upload, got [%d]", calls)
+	}
+	if calls := h.yt.Calls("playlistImages.update"); calls != 0 {
+		t.Fatalf("expected no replacement, got [%d]", calls)
+	}
+}
+
+func TestAddPlaylistToYouTubeFollowsRename(t *testing.T) {
+	h := newHarness(t, videos...)
+
+	playlist := h.sp.AddPlaylist("Road Trip").AddTrack(trackAlpha)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	playlist.Name = "Summer Road Trip"
+	playlist.AddTrack(trackBeta)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != 1 || ytPlaylists[0].Snippet.Title != "Summer Road Trip" {
+		t.Fatalf("expected the YouTube Playlist to be renamed: %+v", ytPlaylists)
+	}
+
+	got := h.yt.PlaylistVideoIds(ytPlaylists[0].Id)
+	if want := []string{"alphavideo1", "betavideo01"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestAddPlaylistToYouTubeSplitsLargePlaylists(t *testing.T) {
+	trackDelta := fakespotify.Track{ID: "delta", Name: "Delta Dub", Artists: []string{"Band"}, DurationMs: 230000}
+	trackOmega := fakespotify.Track{ID: "omega", Name: "Omega Opus", Artists: []string{"Band"}, DurationMs: 240000}
+	h := newHarness(t, append(videos,
+		innertube.Video{VideoId: "deltavideo1", Title: "Band - Delta Dub", Channel: "Band", Length: "3:50"},
+		innertube.Video{VideoId: "omegavideo1", Title: "Band - Omega Opus", Channel: "Band", Length: "4:00"},
+	)...)
+	h.spotify.config.PlaylistItemLimit = 2
+
+	playlist := h.sp.AddPlaylist("Road Trip").AddTrack(trackAlpha).AddTrack(trackBeta).AddTrack(trackGamma)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	// A Track added at the start of the Spotify Playlist does not move the
+	// others between parts
+	playlist.Items = append([]fakespotify.Item{{AddedAt: "2025-01-02T00:00:00Z", AddedBy: "fakeuser", Track: &trackDelta}}, playlist.Items...)
+	playlist.AddTrack(trackOmega)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	want := map[string][]string{
+		"Road Trip":          {"alphavideo1", "betavideo01"},
+		"Road Trip (Part 2)": {"gammavideo1", "deltavideo1"},
+		"Road Trip (Part 3)": {"omegavideo1"},
+	}
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != len(want) {
+		t.Fatalf("expected %d parts, got %+v", len(want), ytPlaylists)
+	}
+	for _, ytPlaylist := range ytPlaylists {
+		if got := h.yt.PlaylistVideoIds(ytPlaylist.Id); !slices.Equal(got, want[ytPlaylist.Snippet.Title]) {
+			t.Errorf("expected [%s] to hold %v, got %v", ytPlaylist.Snippet.Title, want[ytPlaylist.Snippet.Title], got)
+		}
+	}
+}
+
+func TestReviewAdderStartsNewPart(t *testing.T) {
+	h := newHarness(t, videos...)
+	h.spotify.config.PlaylistItemLimit = 2
+
+	// Alpha is queued for review while the first part is empty
+	h.spotify.config.ReviewThreshold = 2
+	playlist := h.sp.AddPlaylist("Road Trip").AddTrack(trackAlpha)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	// The first part is then filled by other Tracks before the review
+	h.spotify.config.ReviewThreshold = 0
+	playlist.Items = nil
+	playlist.AddTrack(trackBeta).AddTrack(trackGamma)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	queue, err := review.Load(h.spotify.config.ReviewQueueFile)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := review.Run(queue, ReviewAdder(h.spotify.config, h.youtube), strings.NewReader("1\n"), &strings.Builder{}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	want := map[string][]string{
+		"Road Trip":          {"betavideo01", "gammavideo1"},
+		"Road Trip (Part 2)": {"alphavideo1"},
+	}
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != len(want) {
+		t.Fatalf("expected %d parts, got %+v", len(want), ytPlaylists)
+	}
+	for _, ytPlaylist := range ytPlaylists {
+		if got := h.yt.PlaylistVideoIds(ytPlaylist.Id); !slices.Equal(got, want[ytPlaylist.Snippet.Title]) {
+			t.Errorf("expected [%s] to hold %v, got %v", ytPlaylist.Snippet.Title, want[ytPlaylist.Snippet.Title], got)
+		}
+	}
+}
+
+func TestMergePlaylistsToYouTube(t *testing.T) {
+	h := newHarness(t, videos...)
+
+	// The same recording under another Spotify ID, sharing an ISRC
+	rerelease := trackBeta
+	rerelease.ID = "betarerelease"
+
+	running := h.sp.AddPlaylist("Workout Running").AddTrack(trackAlpha).AddTrack(trackBeta)
+	lifting := h.sp.AddPlaylist("Workout Lifting").AddTrack(trackGamma).AddTrack(trackAlpha).AddTrack(rerelease)
+	h.sp.AddPlaylist("Chill")
+
+	if _, err := h.spotify.FindPlaylists("workout ["); !errors.Is(err, path.ErrBadPattern) {
+		t.Fatalf("expected a malformed pattern to fail, got [%v]", err)
+	}
+
+	playlists, err := h.spotify.FindPlaylists("workout *")
+	if err != nil {
+		t.Fatalf("FindPlaylists: %v", err)
+	}
+	if len(playlists) != 2 || playlists[0].ID != spotify.ID(running.ID) || playlists[1].ID != spotify.ID(lifting.ID) {
+		t.Fatalf("unexpected Playlists: %+v", playlists)
+	}
+
+	h.spotify.MergePlaylistsToYouTube([]spotify.ID{playlists[0].ID, playlists[1].ID}, MergeOptions{Name: "Workout", Order: MergeInterleave}, h.youtube)
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != 1 || ytPlaylists[0].Snippet.Title != "Workout" {
+		t.Fatalf("unexpected YouTube Playlists: %+v", ytPlaylists)
+	}
+
+	got := h.yt.PlaylistVideoIds(ytPlaylists[0].Id)
+	if want := []string{"alphavideo1", "gammavideo1", "betavideo01"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMergePlaylistsToYouTubeAppliesRules(t *testing.T) {
+	h := newHarness(t, videos...)
+	h.spotify.config.Rules = rules.Rules{Limit: 1}
+
+	running := h.sp.AddPlaylist("Workout Running").AddTrack(trackAlpha).AddTrack(trackBeta)
+	lifting := h.sp.AddPlaylist("Workout Lifting").AddTrack(trackGamma).AddTrack(trackBeta)
+
+	// The limit is applied to each Playlist before they are merged
+	h.spotify.MergePlaylistsToYouTube([]spotify.ID{spotify.ID(running.ID), spotify.ID(lifting.ID)}, MergeOptions{Name: "Workout", Order: MergeBySource}, h.youtube)
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != 1 {
+		t.Fatalf("expected 1 YouTube Playlist, got %d", len(ytPlaylists))
+	}
+	got := h.yt.PlaylistVideoIds(ytPlaylists[0].Id)
+	if want := []string{"alphavideo1", "gammavideo1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMergePlaylistsToYouTubeSkipsUnknownPlaylists(t *testing.T) {
+	h := newHarness(t, videos...)
+
+	running := h.sp.AddPlaylist("Workout Running").AddTrack(trackAlpha).AddTrack(trackBeta)
+
+	h.spotify.MergePlaylistsToYouTube([]spotify.ID{"unknownplaylist", spotify.ID(running.ID)}, MergeOptions{Name: "Workout", Order: MergeBySource}, h.youtube)
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != 1 {
+		t.Fatalf("expected 1 YouTube Playlist, got %d", len(ytPlaylists))
+	}
+	if got, want := h.yt.PlaylistVideoIds(ytPlaylists[0].Id), []string{"alphavideo1", "betavideo01"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// With no Playlist retrieved, nothing is created
+	h.spotify.MergePlaylistsToYouTube([]spotify.ID{"unknownplaylist"}, MergeOptions{Name: "Nothing"}, h.youtube)
+	if got := len(h.yt.Playlists()); got != 1 {
+		t.Fatalf("expected no new YouTube Playlist, got %d Playlists", got)
+	}
+}
+
+func TestAddPlaylistToYouTubeAppliesRules(t *testing.T) {
+	h := newHarness(t, videos...)
+	h.spotify.config.Rules = rules.Rules{
+		Explicit:       rules.ExplicitExclude,
+		ExcludeAddedBy: []string{"friend"},
+		SortByAdded:    true,
+		Reverse:        true,
+		Dedupe:         true,
+		Limit:          2,
+	}
+
+	explicit := trackAlpha
+	explicit.Explicit = true
+
+	item := func(track fakespotify.Track, addedAt, addedBy string) fakespotify.Item {
+		return fakespotify.Item{AddedAt: addedAt, AddedBy: addedBy, Track: &track}
+	}
+	playlist := h.sp.AddPlaylist("Filtered")
+	playlist.Items = []fakespotify.Item{
+		item(explicit, "2025-01-01T00:00:00Z", "fakeuser"),
+		item(trackBeta, "2025-02-01T00:00:00Z", "fakeuser"),
+		item(trackGamma, "2025-03-01T00:00:00Z", "fakeuser"),
+		item(trackBeta, "2025-04-01T00:00:00Z", "fakeuser"),
+		item(trackGamma, "2025-05-01T00:00:00Z", "friend"),
+	}
+
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != 1 {
+		t.Fatalf("expected 1 YouTube Playlist, got %d", len(ytPlaylists))
+	}
+	got := h.yt.PlaylistVideoIds(ytPlaylists[0].Id)
+	if want := []string{"betavideo01", "gammavideo1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestOrderMerged(t *testing.T) {
+	item := func(id, addedAt string, source int) mergeItem {
+		return mergeItem{track: youtube.Track{SpotifyId: id}, addedAt: addedAt, source: source}
+	}
+	sources := [][]mergeItem{
+		{item("a1", "2025-03-01T00:00:00Z", 0), item("a2", "2025-01-01T00:00:00Z", 0), item("a3", "2025-05-01T00:00:00Z", 0)},
+		{item("b1", "2025-02-01T00:00:00Z", 1), item("b2", "2025-04-01T00:00:00Z", 1)},
+	}
+
+	ids := func(items []mergeItem) []string {
+		var ids []string
+		for _, item := range items {
+			ids = append(ids, item.track.SpotifyId)
+		}
+		return ids
+	}
+
+	tests := map[MergeOrder][]string{
+		MergeBySource:   {"a1", "a2", "a3", "b1", "b2"},
+		MergeInterleave: {"a1", "b1", "a2", "b2", "a3"},
+		MergeByAddedAt:  {"a2", "b1", "a1", "b2", "a3"},
+	}
+	for order, want := range tests {
+		if got := ids(orderMerged(sources, MergeOptions{Order: order})); !slices.Equal(got, want) {
+			t.Errorf("%s: expected %v, got %v", order, want, got)
+		}
+	}
+
+	first := ids(orderMerged(sources, MergeOptions{Order: MergeShuffle, Seed: 7}))
+	again := ids(orderMerged(sources, MergeOptions{Order: MergeShuffle, Seed: 7}))
+	if !slices.Equal(first, again) || len(first) != 5 {
+		t.Errorf("expected the same shuffle for the same seed, got %v and %v", first, again)
+	}
+}
+
+func testCover(t *testing.T, fill color.Color) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 640, 640))
+	draw.Draw(img, img.Bounds(), image.NewUniform(fill), image.Point{}, draw.Src)
+
+	var data bytes.Buffer
+	if err := jpeg.Encode(&data, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	return data.Bytes()
+}
+
+func TestPlainText(t *testing.T) {
+	tests := map[string]string{
+		"Songs for the motorway":          "Songs for the motorway",
+		"Rock &amp; roll, it&#x27;s late": "Rock & roll, it's late",
+		`Covers of <a href="spotify:artist:4tZwfgrHOc3mvqYlEYSvVi">Daft Punk</a>`: "Covers of Daft Punk",
+		"": "",
+	}
+
+	for description, want := range tests {
+		if got := plainText(description); got != want {
+			t.Errorf("plainText(%q) = %q, want %q", description, got, want)
+		}
+	}
+}
+
+func TestAddPlaylistToYouTubeSkipsExistingTracks(t *testing.T) {
+	h := newHarness(t, videos...)
+
+	playlist := h.sp.AddPlaylist("Road Trip").AddTrack(trackAlpha).AddTrack(trackBeta)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	playlist.AddTrack(trackGamma)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	ytPlaylistId := h.yt.Playlists()[0].Id
+	if got := h.yt.PlaylistVideoIds(ytPlaylistId); len(got) != 3 {
+		t.Fatalf("expected 3 videos, got %v", got)
+	}
+
+	second := h.spotify.Report().Playlists[1].Totals()
+	if second[report.Duplicate] != 2 || second[report.Inserted] != 1 {
+		t.Fatalf("unexpected second run totals: %v", second)
+	}
+}
+
+func TestAddPlaylistToYouTubeFindsRecordedTracks(t *testing.T) {
+	h := newHarness(t, videos...)
+
+	playlist := h.sp.AddPlaylist("Road Trip").AddTrack(trackAlpha).AddTrack(trackBeta)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	// The fake gives the items titles no Track resembles, so only the videos
+	// recorded by the first run identify them
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	for _, track := range h.spotify.Report().Playlists[1].Tracks {
+		if track.Outcome != report.Duplicate || track.Method != "" {
+			t.Errorf("expected [%s] to be found without searching, got outcome [%s] method [%s]", track.Name, track.Outcome, track.Method)
+		}
+	}
+
+	links, err := syncstate.Load(h.spotify.config.StateFile)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := map[string]string{trackAlpha.ID: "alphavideo1", trackBeta.ID: "betavideo01"}
+	if got := links.Links[playlist.ID].Videos; !maps.Equal(got, want) {
+		t.Errorf("expected recorded videos %v, got %v", want, got)
+	}
+}
+
+func TestAddAllPlaylists(t *testing.T) {
+	h := newHarness(t, videos...)
+
+	h.sp.AddPlaylist("First").AddTrack(trackAlpha)
+	h.sp.AddPlaylist("Second").AddTrack(trackBeta).AddTrack(trackGamma)
+
+	h.spotify.AddAllPlaylists(h.youtube)
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != 2 {
+		t.Fatalf("expected 2 YouTube Playlists, got [%d]", len(ytPlaylists))
+	}
+	if got := h.yt.PlaylistVideoIds(ytPlaylists[1].Id); len(got) != 2 {
+		t.Fatalf("expected 2 videos in the second Playlist, got %v", got)
+	}
+	if h.youtube.Credits != h.yt.QuotaUsed() {
+		t.Fatalf("counted [%d] credits, but [%d] were charged", h.youtube.Credits, h.yt.QuotaUsed())
+	}
+}
+
+func TestAddPlaylistToYouTubeMatchesByISRC(t *testing.T) {
+	h := newHarness(t, videos...)
+	h.music.songs[trackAlpha.ISRC] = innertube.Song{
+		Video: innertube.Video{VideoId: "alphatopic1", Title: "Alpha Wave", Channel: "Band", Length: "3:20"},
+	}
+	// An ISRC YouTube Music does not know returns an unrelated song
+	h.music.songs[trackBeta.ISRC] = innertube.Song{
+		Video: innertube.Video{VideoId: "unrelated01", Title: "Something Else Entirely", Channel: "Other", Length: "3:30"},
+	}
+
+	playlist := h.sp.AddPlaylist("Road Trip").AddTrack(trackAlpha).AddTrack(trackBeta)
+	h.spotify.AddPlaylistToYouTube(spotify.ID(playlist.ID), h.youtube)
+
+	ytPlaylistId := h.yt.Playlists()[0].Id
+	if got, want := h.yt.PlaylistVideoIds(ytPlaylistId), []string{"alphatopic1", "betavideo01"}; !slices.Equal(got, want) {
+		t.Fatalf("expected videos %v, got %v", want, got)
+	}
+
+	tracks := h.spotify.Report().Playlists[0].Tracks
+	if tracks[0].Method != string(youtube.ISRCLookup) || tracks[1].Method != string(youtube.FuzzySearch) {
+		t.Errorf("expected methods [isrc search], got [%s %s]", tracks[0].Method, tracks[1].Method)
+	}
+}
+
+// addAlbum creates a Spotify Album of the three test Tracks, and a YouTube
+// Music album of the given songs
+func (h *harness) addAlbum(songs ...innertube.Song) *fakespotify.Album {
+	h.music.albums["Band Greek Letters"] = innertube.AlbumPage{PlaylistId: "OLAK5uy_greekletters", Title: "Greek Letters", Artist: "Band", Tracks: songs}
+	return h.sp.AddAlbum("Greek Letters", "Band").AddTrack(trackAlpha).AddTrack(trackBeta).AddTrack(trackGamma)
+}
+
+var albumSongs = []innertube.Song{
+	{Video: innertube.Video{VideoId: "alphaalbum1", Title: "Alpha Wave", Channel: "Band", Length: "3:20"}},
+	{Video: innertube.Video{VideoId: "betaalbum01", Title: "Beta Blues", Channel: "Band", Length: "3:30"}},
+	{Video: innertube.Video{VideoId: "gammaalbum1", Title: "Gamma Groove", Channel: "Band", Length: "3:40"}},
+}
+
+func TestAddAlbumToYouTubeCopiesAlbum(t *testing.T) {
+	h := newHarness(t, videos...)
+	album := h.addAlbum(albumSongs...)
+
+	h.spotify.AddAlbumToYouTube(spotify.ID(album.ID), h.youtube)
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != 1 || ytPlaylists[0].Snippet.Title != "Band - Greek Letters" {
+		t.Fatalf("unexpected YouTube Playlists: %+v", ytPlaylists)
+	}
+	if got, want := h.yt.PlaylistVideoIds(ytPlaylists[0].Id), []string{"alphaalbum1", "betaalbum01", "gammaalbum1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected videos %v, got %v", want, got)
+	}
+
+	for _, track := range h.spotify.Report().Playlists[0].Tracks {
+		if track.Method != string(youtube.AlbumLookup) {
+			t.Errorf("expected method [%s], got [%s]", youtube.AlbumLookup, track.Method)
+		}
+	}
+}
+
+func TestAddAlbumToYouTubeFallsBackPerTrack(t *testing.T) {
+	h := newHarness(t, videos...)
+	songs := slices.Clone(albumSongs)
+	songs[1] = innertube.Song{Video: innertube.Video{VideoId: "bonustrack1", Title: "Bonus Interlude", Channel: "Band", Length: "1:10"}}
+	album := h.addAlbum(songs...)
+
+	h.spotify.AddAlbumToYouTube(spotify.ID(album.ID), h.youtube)
+
+	ytPlaylistId := h.yt.Playlists()[0].Id
+	if got, want := h.yt.PlaylistVideoIds(ytPlaylistId), []string{"alphaalbum1", "betavideo01", "gammaalbum1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected videos %v, got %v", want, got)
+	}
+}
+
+func TestAddAlbumToYouTubePairsSongsByPosition(t *testing.T) {
+	h := newHarness(t, videos...)
+	h.music.albums["Band Greek Letters"] = innertube.AlbumPage{PlaylistId: "OLAK5uy_greekletters", Title: "Greek Letters", Artist: "Band", Tracks: albumSongs}
+	unavailable := trackBeta
+	unavailable.Unavailable = true
+	album := h.sp.AddAlbum("Greek Letters", "Band").AddTrack(trackAlpha).AddTrack(unavailable).AddTrack(trackGamma)
+
+	h.spotify.AddAlbumToYouTube(spotify.ID(album.ID), h.youtube)
+
+	ytPlaylistId := h.yt.Playlists()[0].Id
+	if got, want := h.yt.PlaylistVideoIds(ytPlaylistId), []string{"alphaalbum1", "gammaalbum1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected videos %v, got %v", want, got)
+	}
+
+	for _, track := range h.spotify.Report().Playlists[0].Tracks {
+		if track.Method != string(youtube.AlbumLookup) {
+			t.Errorf("expected method [%s] for [%s], got [%s]", youtube.AlbumLookup, track.Name, track.Method)
+		}
+	}
+}
+
+func TestAddAlbumToYouTubeHonoursOverrides(t *testing.T) {
+	h := newHarness(t, videos...)
+	h.youtube.SetOverrides(&youtube.Overrides{
+		Pins:         map[string]string{trackBeta.ID: "betapinned1"},
+		BannedVideos: []string{"gammaalbum1"},
+	})
+	album := h.addAlbum(albumSongs...)
+
+	h.spotify.AddAlbumToYouTube(spotify.ID(album.ID), h.youtube)
+
+	ytPlaylistId := h.yt.Playlists()[0].Id
+	if got, want := h.yt.PlaylistVideoIds(ytPlaylistId), []string{"alphaalbum1", "betapinned1", "gammavideo1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected videos %v, got %v", want, got)
+	}
+
+	tracks := h.spotify.Report().Playlists[0].Tracks
+	if tracks[1].Method != string(youtube.Pinned) {
+		t.Errorf("expected the pinned Track to use method [%s], got [%s]", youtube.Pinned, tracks[1].Method)
+	}
+}
+
+func TestAddAlbumToYouTubeDoesNotLinkPinnedAlbum(t *testing.T) {
+	h := newHarness(t, videos...)
+	h.spotify.config.AlbumMode = config.AlbumLink
+	h.youtube.SetOverrides(&youtube.Overrides{Pins: map[string]string{trackAlpha.ISRC: "alphapinned"}})
+	album := h.addAlbum(albumSongs...)
+
+	h.spotify.AddAlbumToYouTube(spotify.ID(album.ID), h.youtube)
+
+	ytPlaylists := h.yt.Playlists()
+	if len(ytPlaylists) != 1 {
+		t.Fatalf("expected the album to be copied, got %+v", ytPlaylists)
+	}
+	if got, want := h.yt.PlaylistVideoIds(ytPlaylists[0].Id), []string{"alphapinned", "betaalbum01", "gammaalbum1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected videos %v, got %v", want, got)
+	}
+}
+
+func TestAddAlbumToYouTubeLinksAlbum(t *testing.T) {
+	h := newHarness(t, videos...)
+	h.spotify.config.AlbumMode = config.AlbumLink
+	album := h.addAlbum(albumSongs...)
+
+	h.spotify.AddAlbumToYouTube(spotify.ID(album.ID), h.youtube)
+
+	if ytPlaylists := h.yt.Playlists(); len(ytPlaylists) != 0 {
+		t.Fatalf("expected no YouTube Playlists, got %+v", ytPlaylists)
+	}
+
+	albumReport := h.spotify.Report().Playlists[0]
+	if albumReport.YouTubeId != "OLAK5uy_greekletters" {
+		t.Errorf("expected the album playlist to be linked, got [%s]", albumReport.YouTubeId)
+	}
+	if totals := albumReport.Totals(); totals[report.Linked] != 3 {
+		t.Errorf("expected 3 linked, got %v", totals)
+	}
+}
+
+func TestAddAlbumToYouTubeSearchesEachTrack(t *testing.T) {
+	h := newHarness(t, videos...)
+	h.spotify.config.AlbumMode = config.AlbumTracks
+	album := h.addAlbum(albumSongs...)
+
+	h.spotify.AddAlbumToYouTube(spotify.ID(album.ID), h.youtube)
+
+	ytPlaylistId := h.yt.Playlists()[0].Id
+	if got, want := h.yt.PlaylistVideoIds(ytPlaylistId), []string{"alphavideo1", "betavideo01", "gammavideo1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected videos %v, got %v", want, got)
+	}
+}
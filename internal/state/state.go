@@ -0,0 +1,92 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package state remembers what earlier runs did to each converted Playlist,
+// so that later runs can skip work which is already done.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
+)
+
+var logger = logging.For("state")
+
+// Link is what is remembered about a Spotify Playlist or Album converted to
+// YouTube
+type Link struct {
+	// YouTubeId is the YouTube Playlist it was converted to
+	YouTubeId string `json:"youtubeId"`
+	// CoverURL is the Spotify cover image last copied to the YouTube Playlist
+	CoverURL string `json:"coverUrl,omitempty"`
+	// Videos holds the video each Spotify Track was added as, by Track ID, so
+	// that the Track is found in the Playlist without comparing titles
+	Videos map[string]string `json:"videos,omitempty"`
+}
+
+// State is the set of Links stored in the state file, by Spotify ID
+type State struct {
+	path  string
+	Links map[string]*Link `json:"links"`
+}
+
+// Load reads the state file at path. A missing file is an empty State.
+func Load(path string) (*State, error) {
+	state := &State{path: path, Links: make(map[string]*Link)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Links == nil {
+		state.Links = make(map[string]*Link)
+	}
+
+	return state, nil
+}
+
+// Link returns the Link of a Spotify ID, creating an empty one if there is
+// none yet
+func (s *State) Link(spotifyId string) *Link {
+	link, ok := s.Links[spotifyId]
+	if !ok {
+		link = &Link{}
+		s.Links[spotifyId] = link
+	}
+	return link
+}
+
+// Save writes the State back to its file
+func (s *State) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("Saving sync state", "links", len(s.Links), "file", s.path)
+	return os.WriteFile(s.path, data, 0600)
+}
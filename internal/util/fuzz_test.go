@@ -0,0 +1,99 @@
+/*
+ *    Copyright (c) 2025 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package util
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func addTitleSeeds(f *testing.F) []titlePair {
+	pairs := loadTitlePairs(f)
+	for _, pair := range pairs {
+		f.Add(pair.Query(), pair.Title)
+	}
+	f.Add("", "")
+	f.Add("AC-DC", "ac dc")
+	f.Add("  ((hd)) ..", " Video .")
+	f.Add("\xff\xfe", "İstanbul")
+	return pairs
+}
+
+func FuzzNormalizeTitle(f *testing.F) {
+	addTitleSeeds(f)
+	f.Add("Run [feat. A, B & C]", "a(ft)b")
+	f.Add("ｶﾞｯｺｳ きゃりーぱみゅぱみゅ", "Кино - Группа крови")
+
+	normalizers := []*Normalizer{DefaultNormalizer, {Transliterate: false}}
+
+	f.Fuzz(func(t *testing.T, title, _ string) {
+		for _, normalizer := range normalizers {
+			normalized := normalizer.Normalize(title)
+
+			if again := normalizer.Normalize(normalized.Text); again.Text != normalized.Text || len(again.Featured) != 0 {
+				t.Fatalf("not idempotent: %q -> %#v -> %#v", title, normalized, again)
+			}
+			for _, text := range append([]string{normalized.Text}, normalized.Featured...) {
+				if strings.Contains(text, "  ") || strings.TrimSpace(text) != text {
+					t.Fatalf("untidy whitespace: %q -> %q", title, text)
+				}
+			}
+		}
+	})
+}
+
+func FuzzSimilarity(f *testing.F) {
+	addTitleSeeds(f)
+
+	f.Fuzz(func(t *testing.T, a, b string) {
+		for _, algorithm := range Algorithms {
+			score := algorithm.Similarity(a, b)
+
+			if score < 0 || score > 1 {
+				t.Fatalf("%s.Similarity(%q, %q) = %v, outside [0, 1]", algorithm, a, b, score)
+			}
+			if reverse := algorithm.Similarity(b, a); reverse != score {
+				t.Fatalf("%s.Similarity is not symmetric: %v != %v", algorithm, score, reverse)
+			}
+			if self := algorithm.Similarity(a, a); self != 1 {
+				t.Fatalf("%s.Similarity(%q, %q) = %v, want 1", algorithm, a, a, self)
+			}
+		}
+	})
+}
+
+func FuzzLevenshteinDistance(f *testing.F) {
+	addTitleSeeds(f)
+
+	f.Fuzz(func(t *testing.T, a, b string) {
+		distance := LevenshteinDistance(a, b)
+
+		if distance < 0 {
+			t.Fatalf("negative distance %d", distance)
+		}
+		if reverse := LevenshteinDistance(b, a); reverse != distance {
+			t.Fatalf("LevenshteinDistance is not symmetric: %d != %d", distance, reverse)
+		}
+		longest := max(utf8.RuneCountInString(NormalizeTitle(a)), utf8.RuneCountInString(NormalizeTitle(b)))
+		if distance > longest {
+			t.Fatalf("LevenshteinDistance(%q, %q) = %d, longer than both titles", a, b, distance)
+		}
+		if NormalizeTitle(a) == NormalizeTitle(b) && distance != 0 {
+			t.Fatalf("equal titles %q and %q have distance %d", a, b, distance)
+		}
+	})
+}
@@ -0,0 +1,161 @@
+/*
+ *    Copyright (c) 2025 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package util
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Title is a normalized title, with any featured artists separated from it
+type Title struct {
+	// Text is the normalized title without its featured artists
+	Text string
+	// Featured holds the normalized names of the featured artists
+	Featured []string
+}
+
+// Normalizer reduces titles to a canonical form so that the same recording
+// titled differently by Spotify and by a YouTube uploader compares as equal.
+type Normalizer struct {
+	// Transliterate romanizes Cyrillic, Greek and Japanese kana, so that a
+	// title matches its romanized upload.
+	Transliterate bool
+}
+
+// DefaultNormalizer is the Normalizer used by title comparisons which are not
+// given one of their own. It is not changed by the configuration, which gives
+// each Matcher its own Normalizer instead.
+var DefaultNormalizer = &Normalizer{Transliterate: true}
+
+// badPhrases are the words removed from titles before they are compared, as
+// they describe the upload rather than the Track.
+var badPhrases = regexp.MustCompile(`\b(official|music video|video|clip|audio|lyrics|lyric|visualizer|visualiser|hd|hq|4k)\b`)
+
+// bracketedFeature matches "(feat. X)", "[ft. X]" and "(with X)"
+var bracketedFeature = regexp.MustCompile(`[(\[]\s*(?:feat\.?|ft\.?|featuring|with)\s+([^()\[\]]*)[)\]]`)
+
+// bareFeature matches "feat. X" running to the end of the title, or to the
+// next bracket or " - " separator.
+var bareFeature = regexp.MustCompile(`(?:^|\s)(?:feat\.?|ft\.?|featuring)\s+([^()\[\]]*?)(?:\s+-\s|[()\[\]]|$)`)
+
+// featureSeparator splits a list of featured artists
+var featureSeparator = regexp.MustCompile(`\s*(?:,|&|\band\b|\bx\b)\s*`)
+
+// Normalize folds a title to lowercase ASCII where possible, extracts its
+// featured artists, and strips the punctuation and phrases which do not
+// identify the Track. Normalizing the Text again leaves it unchanged.
+func (n *Normalizer) Normalize(title string) Title {
+	folded := n.fold(title)
+
+	text, featured := extractFeatured(folded, bracketedFeature)
+	text, bare := extractFeatured(text, bareFeature)
+	featured = append(featured, bare...)
+
+	text = clean(text)
+
+	// Removing punctuation can expose a feature marker, as in "a(ft)b"
+	text, exposed := extractFeatured(text, bareFeature)
+	featured = append(featured, exposed...)
+
+	var names []string
+	for _, name := range featured {
+		for _, artist := range featureSeparator.Split(name, -1) {
+			if artist = clean(artist); artist != "" {
+				names = append(names, artist)
+			}
+		}
+	}
+
+	return Title{Text: strings.Join(strings.Fields(text), " "), Featured: names}
+}
+
+// fold lowercases the title, applies compatibility decomposition so that
+// full-width and other variant forms become their plain equivalents, removes
+// diacritics from Latin, Greek and Cyrillic letters, and optionally
+// transliterates.
+func (n *Normalizer) fold(title string) string {
+	decomposed := norm.NFKD.String(strings.ToLower(title))
+
+	var builder strings.Builder
+	var base rune
+	for _, r := range decomposed {
+		if unicode.Is(unicode.Mn, r) {
+			if unicode.In(base, unicode.Latin, unicode.Greek, unicode.Cyrillic) {
+				continue
+			}
+		} else {
+			base = r
+		}
+		builder.WriteRune(r)
+	}
+
+	folded := strings.ToLower(norm.NFC.String(builder.String()))
+	if n.Transliterate {
+		folded = transliterate(folded)
+	}
+	return folded
+}
+
+// extractFeatured removes each feature credit matched by pattern, returning
+// the remaining title and the credited artists.
+func extractFeatured(title string, pattern *regexp.Regexp) (string, []string) {
+	var featured []string
+	var builder strings.Builder
+
+	last := 0
+	for _, match := range pattern.FindAllStringSubmatchIndex(title, -1) {
+		end := match[1]
+		if pattern == bareFeature {
+			// Keep the separator that ended the credit
+			end = match[3]
+		}
+
+		builder.WriteString(title[last:match[0]])
+		builder.WriteString(" ")
+		featured = append(featured, title[match[2]:match[3]])
+		last = end
+	}
+	builder.WriteString(title[last:])
+
+	return builder.String(), featured
+}
+
+// clean canonicalizes separators, replaces punctuation with spaces, removes
+// the bad phrases and collapses whitespace. Apostrophes and full stops are
+// dropped rather than replaced, so that "Don't" matches "Dont" and "R.E.M."
+// matches "REM".
+func clean(text string) string {
+	var builder strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '&':
+			builder.WriteString(" and ")
+		case r == '\'' || r == '’' || r == '‘' || r == '.':
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			builder.WriteRune(' ')
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	cleaned := badPhrases.ReplaceAllString(builder.String(), "")
+
+	return strings.Join(strings.Fields(cleaned), " ")
+}
@@ -0,0 +1,75 @@
+/*
+ *    Copyright (c) 2025 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		title string
+		want  Title
+	}{
+		{"Beyoncé - Halo", Title{Text: "beyonce halo"}},
+		{"ＹＯＡＳＯＢＩ", Title{Text: "yoasobi"}},
+		{"Simon & Garfunkel", Title{Text: "simon and garfunkel"}},
+		{"Queen – Bohemian Rhapsody", Title{Text: "queen bohemian rhapsody"}},
+		{"Uptown Funk (feat. Bruno Mars)", Title{Text: "uptown funk", Featured: []string{"bruno mars"}}},
+		{"Uptown Funk (Official Video) ft. Bruno Mars", Title{Text: "uptown funk", Featured: []string{"bruno mars"}}},
+		{"Song featuring A - Remix", Title{Text: "song remix", Featured: []string{"a"}}},
+		{"I Don't Care (with Justin Bieber)", Title{Text: "i dont care", Featured: []string{"justin bieber"}}},
+		{"Run [feat. A, B & C]", Title{Text: "run", Featured: []string{"a", "b", "c"}}},
+		{"Running Up That Hill (A Deal With God)", Title{Text: "running up that hill a deal with god"}},
+		{"R.E.M. - Losing My Religion", Title{Text: "rem losing my religion"}},
+	}
+
+	for _, test := range tests {
+		if got := DefaultNormalizer.Normalize(test.title); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Normalize(%q) = %#v, want %#v", test.title, got, test.want)
+		}
+	}
+}
+
+func TestNormalizeTransliterates(t *testing.T) {
+	tests := map[string]string{
+		"Кино - Группа крови":    "kino gruppa krovi",
+		"Ёлка":                   "elka",
+		"Αλεξάνδρα":              "alexandra",
+		"アイドル":                   "aidoru",
+		"ちゃっと":                   "chatto",
+		"きゃりーぱみゅぱみゅ":             "kyaripamyupamyu",
+		"夜に駆ける":                  "夜ni駆keru",
+		"ｶﾞｯｺｳ":                  "gakkou",
+		"Björk - Jóga":           "bjork joga",
+		"Sigur Rós - Hoppípolla": "sigur ros hoppipolla",
+	}
+
+	for input, want := range tests {
+		if got := DefaultNormalizer.Normalize(input).Text; got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	plain := &Normalizer{}
+	if got := plain.Normalize("Кино").Text; got != "кино" {
+		t.Errorf("expected Cyrillic to be kept without transliteration, got %q", got)
+	}
+	if got := plain.Normalize("ガッコウ").Text; got != "ガッコウ" {
+		t.Errorf("expected voiced kana to be kept without transliteration, got %q", got)
+	}
+}
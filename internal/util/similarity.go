@@ -0,0 +1,262 @@
+/*
+ *    Copyright (c) 2025 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/agnivade/levenshtein"
+)
+
+// Algorithm is a way of scoring the Similarity of two titles
+type Algorithm string
+
+const (
+	// Levenshtein scores the edit distance relative to the longer title
+	Levenshtein Algorithm = "levenshtein"
+	// JaroWinkler favours titles which share a common prefix
+	JaroWinkler Algorithm = "jaro-winkler"
+	// TokenSet ignores word order and words present in only one title, so
+	// that a title contained in the other scores 1
+	TokenSet Algorithm = "token-set"
+	// TokenSort ignores word order
+	TokenSort Algorithm = "token-sort"
+)
+
+// Algorithms lists every supported Algorithm
+var Algorithms = []Algorithm{Levenshtein, JaroWinkler, TokenSet, TokenSort}
+
+// ParseAlgorithm returns the Algorithm with the given name
+func ParseAlgorithm(name string) (Algorithm, error) {
+	for _, algorithm := range Algorithms {
+		if string(algorithm) == name {
+			return algorithm, nil
+		}
+	}
+	return "", fmt.Errorf("unknown similarity algorithm [%s], expected one of %v", name, Algorithms)
+}
+
+// Similarity normalizes two titles with the DefaultNormalizer and scores them
+// between 0 (completely different) and 1 (identical) using the Algorithm.
+func (a Algorithm) Similarity(stringA, stringB string) float64 {
+	return Matcher{Algorithm: a}.Similarity(stringA, stringB)
+}
+
+// Matcher scores titles with an Algorithm after normalizing them with its
+// own Normalizer, so that each run can choose how titles are normalized
+type Matcher struct {
+	Algorithm Algorithm
+	// Normalizer normalizes both titles. Nil uses the DefaultNormalizer.
+	Normalizer *Normalizer
+}
+
+// Normalize normalizes a title with the Normalizer of the Matcher
+func (m Matcher) Normalize(title string) Title {
+	normalizer := m.Normalizer
+	if normalizer == nil {
+		normalizer = DefaultNormalizer
+	}
+	return normalizer.Normalize(title)
+}
+
+// Similarity normalizes two titles and scores them between 0 (completely
+// different) and 1 (identical) using the Algorithm of the Matcher.
+func (m Matcher) Similarity(stringA, stringB string) float64 {
+	return m.Score(m.Normalize(stringA), m.Normalize(stringB))
+}
+
+// Score scores two normalized titles between 0 (completely different) and 1
+// (identical) using the Algorithm of the Matcher. Featured artists are not
+// compared, as uploaders credit them inconsistently.
+func (m Matcher) Score(titleA, titleB Title) float64 {
+	var score float64
+	switch m.Algorithm {
+	case JaroWinkler:
+		score = jaroWinkler(titleA.Text, titleB.Text)
+	case TokenSet:
+		score = tokenSetRatio(titleA.Text, titleB.Text)
+	case TokenSort:
+		score = tokenSortRatio(titleA.Text, titleB.Text)
+	default:
+		score = levenshteinRatio(titleA.Text, titleB.Text)
+	}
+
+	logger.Debug("Similarity", "algorithm", m.Algorithm, "a", titleA.Text, "b", titleB.Text, "score", score)
+	return score
+}
+
+// TrackQueries normalizes the titles a Track may be uploaded under: its artist
+// followed by its name, and, when the name credits featured artists, the
+// artist and featured artists followed by the name, so that "A - X (feat. B)"
+// also matches an upload titled "A & B - X".
+func (m Matcher) TrackQueries(artist, name string) []Title {
+	track := m.Normalize(name)
+	queries := []Title{m.Normalize(artist + " " + name)}
+	if len(track.Featured) == 0 {
+		return queries
+	}
+
+	// Credit the artists as uploaders list them, as in "A, B & C"
+	artists := append([]string{artist}, track.Featured...)
+	last := len(artists) - 1
+	credits := m.Normalize(strings.Join(artists[:last], ", ") + " & " + artists[last])
+	return append(queries, Title{Text: strings.TrimSpace(credits.Text + " " + track.Text)})
+}
+
+// ScoreBest scores a normalized title against each of the queries, returning
+// the best score.
+func (m Matcher) ScoreBest(queries []Title, title Title) float64 {
+	var best float64
+	for _, query := range queries {
+		best = max(best, m.Score(query, title))
+	}
+	return best
+}
+
+// TrackSimilarity scores a title against the TrackQueries of a Track
+func (m Matcher) TrackSimilarity(artist, name, title string) float64 {
+	return m.ScoreBest(m.TrackQueries(artist, name), m.Normalize(title))
+}
+
+// levenshteinRatio is 1 minus the edit distance relative to the length of the
+// longer string
+func levenshteinRatio(a, b string) float64 {
+	longest := max(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	if longest == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein.ComputeDistance(a, b))/float64(longest)
+}
+
+// jaroWinkler computes the Jaro-Winkler similarity, boosting the Jaro score
+// by up to four characters of common prefix.
+func jaroWinkler(a, b string) float64 {
+	runesA, runesB := []rune(a), []rune(b)
+	// Always match from the shorter string, so that the score is symmetric
+	if len(runesA) > len(runesB) || (len(runesA) == len(runesB) && a > b) {
+		runesA, runesB = runesB, runesA
+	}
+
+	if len(runesA) == 0 && len(runesB) == 0 {
+		return 1
+	}
+	if len(runesA) == 0 {
+		return 0
+	}
+
+	window := max(len(runesB)/2-1, 0)
+	matchedA := make([]bool, len(runesA))
+	matchedB := make([]bool, len(runesB))
+
+	matches := 0
+	for i, r := range runesA {
+		for j := max(0, i-window); j < min(len(runesB), i+window+1); j++ {
+			if !matchedB[j] && runesB[j] == r {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i, r := range runesA {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if r != runesB[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(runesA)) + m/float64(len(runesB)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(runesA)) && runesA[prefix] == runesB[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// tokenSortRatio compares the words of each string in sorted order
+func tokenSortRatio(a, b string) float64 {
+	return levenshteinRatio(sortedTokens(a), sortedTokens(b))
+}
+
+// tokenSetRatio compares the words common to both strings with the common
+// words plus those unique to each string, taking the best score.
+func tokenSetRatio(a, b string) float64 {
+	tokensA, tokensB := tokenSet(a), tokenSet(b)
+	if len(tokensA) == 0 || len(tokensB) == 0 {
+		if len(tokensA) == len(tokensB) {
+			return 1
+		}
+		return 0
+	}
+
+	var common, onlyA, onlyB []string
+	for token := range tokensA {
+		if tokensB[token] {
+			common = append(common, token)
+		} else {
+			onlyA = append(onlyA, token)
+		}
+	}
+	for token := range tokensB {
+		if !tokensA[token] {
+			onlyB = append(onlyB, token)
+		}
+	}
+	sort.Strings(common)
+	sort.Strings(onlyA)
+	sort.Strings(onlyB)
+
+	intersection := strings.Join(common, " ")
+	withA := strings.TrimSpace(intersection + " " + strings.Join(onlyA, " "))
+	withB := strings.TrimSpace(intersection + " " + strings.Join(onlyB, " "))
+
+	if intersection == "" {
+		return levenshteinRatio(withA, withB)
+	}
+	return max(levenshteinRatio(intersection, withA), levenshteinRatio(intersection, withB), levenshteinRatio(withA, withB))
+}
+
+func sortedTokens(text string) string {
+	tokens := strings.Fields(text)
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+func tokenSet(text string) map[string]bool {
+	set := make(map[string]bool)
+	for _, token := range strings.Fields(text) {
+		set[token] = true
+	}
+	return set
+}
@@ -0,0 +1,107 @@
+/*
+ *    Copyright (c) 2025 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package util
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAlgorithmSimilarity(t *testing.T) {
+	tests := []struct {
+		algorithm Algorithm
+		a, b      string
+		want      float64
+	}{
+		{Levenshtein, "creep", "creek", 0.8},
+		{Levenshtein, "", "", 1},
+		{JaroWinkler, "martha", "marhta", 0.9611},
+		{JaroWinkler, "dixon", "dicksonx", 0.8133},
+		// Three half-transpositions, which count as 1.5 transpositions
+		{JaroWinkler, "abcxyz", "bcaxyz", 0.9167},
+		{JaroWinkler, "abc", "", 0},
+		{TokenSort, "one more time daft punk", "Daft Punk - One More Time", 1},
+		{TokenSort, "a b", "a c", 0.6667},
+		{TokenSet, "Daft Punk One More Time", "One More Time", 1},
+		{TokenSet, "Daft Punk One More Time", "Daft Punk - One More Time (Piano Cover)", 1},
+		{TokenSet, "abc", "xyz", 0},
+		{TokenSet, "", "abc", 0},
+	}
+
+	for _, test := range tests {
+		got := test.algorithm.Similarity(test.a, test.b)
+		if math.Abs(got-test.want) > 0.0001 {
+			t.Errorf("%s.Similarity(%q, %q) = %.4f, want %.4f", test.algorithm, test.a, test.b, got, test.want)
+		}
+	}
+}
+
+func TestMatcherUsesItsNormalizer(t *testing.T) {
+	transliterating := Matcher{Algorithm: TokenSort, Normalizer: &Normalizer{Transliterate: true}}
+	plain := Matcher{Algorithm: TokenSort, Normalizer: &Normalizer{Transliterate: false}}
+
+	if got := transliterating.Similarity("Кино", "Kino"); got != 1 {
+		t.Errorf("expected a transliterated title to match, got %.4f", got)
+	}
+	if got := plain.Similarity("Кино", "Kino"); got != 0 {
+		t.Errorf("expected an untransliterated title not to match, got %.4f", got)
+	}
+	if !DefaultNormalizer.Transliterate {
+		t.Error("expected the DefaultNormalizer to be unchanged")
+	}
+}
+
+func TestMatcherTrackSimilarityCreditsFeaturedArtists(t *testing.T) {
+	tests := []struct {
+		name   string
+		artist string
+		track  string
+		title  string
+	}{
+		{"ampersand", "Daft Punk", "Get Lucky (feat. Pharrell Williams)", "Daft Punk & Pharrell Williams - Get Lucky"},
+		{"bare feat", "Calvin Harris", "This Is What You Came For feat. Rihanna", "Calvin Harris & Rihanna - This Is What You Came For"},
+		{"several", "A", "X (feat. B & C)", "A, B & C - X"},
+		{"credited in the upload", "Daft Punk", "Get Lucky", "Daft Punk - Get Lucky (feat. Pharrell Williams)"},
+	}
+
+	for _, algorithm := range Algorithms {
+		matcher := Matcher{Algorithm: algorithm}
+		for _, test := range tests {
+			t.Run(string(algorithm)+"/"+test.name, func(t *testing.T) {
+				if got := matcher.TrackSimilarity(test.artist, test.track, test.title); got != 1 {
+					t.Errorf("TrackSimilarity(%q, %q, %q) = %.4f, expected 1", test.artist, test.track, test.title, got)
+				}
+			})
+		}
+	}
+
+	matcher := Matcher{Algorithm: TokenSort}
+	if got := matcher.TrackSimilarity("A", "X (feat. B)", "C & D - Y"); got >= 0.5 {
+		t.Errorf("expected a different song not to match, got %.4f", got)
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	for _, algorithm := range Algorithms {
+		if parsed, err := ParseAlgorithm(string(algorithm)); err != nil || parsed != algorithm {
+			t.Errorf("ParseAlgorithm(%q) = %q, %v", algorithm, parsed, err)
+		}
+	}
+
+	if _, err := ParseAlgorithm("soundex"); err == nil {
+		t.Error("expected an error for an unknown algorithm")
+	}
+}
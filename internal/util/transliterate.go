@@ -0,0 +1,133 @@
+/*
+ *    Copyright (c) 2025 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package util
+
+import (
+	"strings"
+	"unicode"
+)
+
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'ґ': "g", 'д': "d", 'е': "e", 'є': "ye",
+	'ж': "zh", 'з': "z", 'и': "i", 'і': "i", 'ї': "yi", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ў': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y",
+	'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+var greek = map[rune]string{
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+}
+
+// kana romanizes hiragana using Hepburn. Katakana is converted to hiragana
+// first.
+var kana = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+}
+
+// smallKana combine with the preceding kana, as in "きゃ" (kya)
+var smallKana = map[rune]string{'ゃ': "a", 'ゅ': "u", 'ょ': "o"}
+
+// transliterate romanizes Cyrillic, Greek and Japanese kana. Kanji and other
+// scripts are left unchanged, as they cannot be romanized without a
+// dictionary.
+func transliterate(text string) string {
+	runes := []rune(text)
+
+	var builder strings.Builder
+	double := false
+	for i := 0; i < len(runes); i++ {
+		r := toHiragana(runes[i])
+
+		if latin, ok := cyrillic[r]; ok {
+			builder.WriteString(latin)
+			continue
+		}
+		if latin, ok := greek[r]; ok {
+			builder.WriteString(latin)
+			continue
+		}
+
+		switch r {
+		case 'っ':
+			// Sokuon doubles the consonant which follows
+			double = true
+			continue
+		case 'ー':
+			continue
+		}
+
+		latin, ok := kana[r]
+		if !ok {
+			if vowel, small := smallKana[r]; small {
+				latin, ok = "y"+vowel, true
+			} else {
+				double = false
+				builder.WriteRune(runes[i])
+				continue
+			}
+		}
+
+		if i+1 < len(runes) && strings.HasSuffix(latin, "i") && len(latin) > 1 {
+			if vowel, small := smallKana[toHiragana(runes[i+1])]; small {
+				latin = strings.TrimSuffix(latin, "i")
+				if !strings.HasSuffix(latin, "sh") && !strings.HasSuffix(latin, "ch") && !strings.HasSuffix(latin, "j") {
+					latin += "y"
+				}
+				latin += vowel
+				i++
+			}
+		}
+
+		if double && !strings.ContainsRune("aiueon", rune(latin[0])) {
+			if strings.HasPrefix(latin, "ch") {
+				builder.WriteByte('t')
+			} else {
+				builder.WriteByte(latin[0])
+			}
+		}
+		double = false
+		builder.WriteString(latin)
+	}
+
+	return builder.String()
+}
+
+// toHiragana converts katakana to the equivalent hiragana
+func toHiragana(r rune) rune {
+	if unicode.In(r, unicode.Katakana) && r >= 'ァ' && r <= 'ヶ' {
+		return r - ('ァ' - 'ぁ')
+	}
+	return r
+}
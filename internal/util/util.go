@@ -17,27 +17,14 @@
 package util
 
 import (
-	"fmt"
-	"log"
 	"os/exec"
-	"regexp"
-	"strings"
 
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
 	"github.com/agnivade/levenshtein"
 	"github.com/zmb3/spotify/v2"
 )
 
-const MaxDistance int = 5
-
-var badPhrases = []string{
-	"\\[", "\\]", "\\(", "\\)", "-", ",",
-	"clip",
-	"hd",
-	"lyrics",
-	"video",
-	"with",
-	"official",
-}
+var logger = logging.For("util")
 
 // CheckIfCommandExists checks if executable 'e' is in PATH
 func CheckIfCommandExists(e ...string) bool {
@@ -56,7 +43,7 @@ func CheckIfCommandExists(e ...string) bool {
 }
 
 func RemoveIndexString(original []string, index int) []string {
-	log.Printf("Removing Item [%d] [%s]", index, original[index])
+	logger.Debug("Removing item", "index", index, "item", original[index])
 
 	modified := make([]string, 0)
 	modified = append(modified, original[:index]...)
@@ -65,7 +52,7 @@ func RemoveIndexString(original []string, index int) []string {
 }
 
 func RemoveIndexTrack(original []spotify.PlaylistTrack, index int) []spotify.PlaylistTrack {
-	log.Printf("Removing Item [%d] [%s]", index, original[index].Track.Name)
+	logger.Debug("Removing item", "index", index, "item", original[index].Track.Name)
 
 	modified := make([]spotify.PlaylistTrack, 0)
 	modified = append(modified, original[:index]...)
@@ -74,33 +61,18 @@ func RemoveIndexTrack(original []spotify.PlaylistTrack, index int) []spotify.Pla
 }
 
 func LevenshteinDistance(stringA, stringB string) int {
-	cleanedA := strings.ToLower(stringA)
-	cleanedB := strings.ToLower(stringB)
-
-	cleanedA = cleanTitle(cleanedA)
-	cleanedB = cleanTitle(cleanedB)
+	cleanedA := NormalizeTitle(stringA)
+	cleanedB := NormalizeTitle(stringB)
 
 	distance := levenshtein.ComputeDistance(cleanedA, cleanedB)
-	log.Printf("Levenshtein Distance between strings \n[%s] and \n[%s]\nis [%d]", cleanedA, cleanedB, distance)
+	logger.Debug("Levenshtein distance", "a", cleanedA, "b", cleanedB, "distance", distance)
 
 	return distance
 }
 
-func cleanTitle(original string) string {
-	newString := original
-
-	for _, replacement := range badPhrases {
-
-		reString := fmt.Sprintf("(?i)%s", replacement)
-		re := regexp.MustCompile(reString)
-
-		newString = re.ReplaceAllString(newString, "")
-	}
-
-	reString := "(\\s){2,}"
-	re := regexp.MustCompile(reString)
-
-	newString = re.ReplaceAllString(newString, " ")
-
-	return strings.Trim(newString, " .")
+// NormalizeTitle returns the normalized Text of a title using the
+// DefaultNormalizer. Normalizing a title twice gives the same result as
+// normalizing it once.
+func NormalizeTitle(title string) string {
+	return DefaultNormalizer.Normalize(title).Text
 }
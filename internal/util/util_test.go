@@ -0,0 +1,188 @@
+/*
+ *    Copyright (c) 2025 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"text/tabwriter"
+)
+
+// titlePair is a Spotify Track and a YouTube video title, with the verdict of
+// whether the video is that Track.
+type titlePair struct {
+	Artist string `json:"artist"`
+	Name   string `json:"name"`
+	Title  string `json:"title"`
+	Same   bool   `json:"same"`
+	Note   string `json:"note,omitempty"`
+}
+
+// Query is the search query the converter builds for the Track
+func (p titlePair) Query() string {
+	return p.Artist + " " + p.Name
+}
+
+func loadTitlePairs(tb testing.TB) []titlePair {
+	tb.Helper()
+
+	data, err := os.ReadFile("testdata/titles.json")
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	var pairs []titlePair
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		tb.Fatal(err)
+	}
+	return pairs
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := map[string]string{
+		"AC-DC":                 "ac dc",
+		"AC/DC - Back In Black": "ac dc back in black",
+		"Daft Punk - One More Time (Official Video)": "daft punk one more time",
+		"Eminem - Without Me":                        "eminem without me",
+		"Shadow of the Day [HD]":                     "shadow of the day",
+		"Hips Don't Lie, Official HD":                "hips dont lie",
+		"  spaced   out  ":                           "spaced out",
+		"(Lyrics)":                                   "",
+		"Song.":                                      "song",
+	}
+
+	for input, want := range tests {
+		if got := NormalizeTitle(input); got != want {
+			t.Errorf("NormalizeTitle(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"One More Time", "one more time", 0},
+		{"Daft Punk One More Time", "Daft Punk - One More Time (Official Video)", 0},
+		{"Creep", "Creek", 1},
+		{"AC/DC", "AC-DC", 0},
+		{"Beyoncé", "Beyonce", 0},
+		{"Halo", "", 4},
+	}
+
+	for _, test := range tests {
+		if got := LevenshteinDistance(test.a, test.b); got != test.want {
+			t.Errorf("LevenshteinDistance(%q, %q) = %d, want %d", test.a, test.b, got, test.want)
+		}
+		if got := LevenshteinDistance(test.b, test.a); got != test.want {
+			t.Errorf("LevenshteinDistance(%q, %q) = %d, want %d", test.b, test.a, got, test.want)
+		}
+	}
+}
+
+// matcher decides whether a YouTube title is the Track searched for
+type matcher struct {
+	name string
+	// minPrecision and minRecall are the scores reached when the matcher was
+	// last changed, so that a regression fails the test.
+	minPrecision, minRecall float64
+	same                    func(query, title string) bool
+}
+
+// legacyMaxDistance is the absolute edit distance once used to detect
+// duplicates, kept as a baseline for the relative scores.
+const legacyMaxDistance = 5
+
+var matchers = []matcher{
+	{
+		name:         fmt.Sprintf("levenshtein distance <= %d", legacyMaxDistance),
+		minPrecision: 1.0, minRecall: 0.78,
+		same: func(query, title string) bool {
+			return LevenshteinDistance(query, title) <= legacyMaxDistance
+		},
+	},
+	similarityMatcher(Levenshtein, 0.5, 0.78, 0.97),
+	similarityMatcher(JaroWinkler, 0.9, 0.82, 0.86),
+	similarityMatcher(TokenSet, 0.75, 0.82, 1.0),
+	// The default review and duplicate thresholds
+	similarityMatcher(TokenSort, 0.5, 0.80, 1.0),
+	similarityMatcher(TokenSort, 0.7, 1.0, 0.86),
+}
+
+func similarityMatcher(algorithm Algorithm, threshold, minPrecision, minRecall float64) matcher {
+	return matcher{
+		name:         fmt.Sprintf("%s >= %.2f", algorithm, threshold),
+		minPrecision: minPrecision, minRecall: minRecall,
+		same: func(query, title string) bool {
+			return algorithm.Similarity(query, title) >= threshold
+		},
+	}
+}
+
+// TestMatchingPrecisionRecall scores each matcher against the title corpus.
+// Run with -v to see the report and the pairs each matcher gets wrong.
+func TestMatchingPrecisionRecall(t *testing.T) {
+	pairs := loadTitlePairs(t)
+
+	var report strings.Builder
+	w := tabwriter.NewWriter(&report, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "MATCHER\tTP\tFP\tFN\tTN\tPRECISION\tRECALL")
+
+	for _, m := range matchers {
+		var tp, fp, fn, tn int
+		for _, pair := range pairs {
+			got := m.same(pair.Query(), pair.Title)
+			switch {
+			case got && pair.Same:
+				tp++
+			case got && !pair.Same:
+				fp++
+				t.Logf("%s: false positive [%s] ~ [%s]", m.name, pair.Query(), pair.Title)
+			case !got && pair.Same:
+				fn++
+				t.Logf("%s: false negative [%s] ~ [%s]", m.name, pair.Query(), pair.Title)
+			default:
+				tn++
+			}
+		}
+
+		precision := ratio(tp, tp+fp)
+		recall := ratio(tp, tp+fn)
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.2f\t%.2f\n", m.name, tp, fp, fn, tn, precision, recall)
+
+		if precision < m.minPrecision {
+			t.Errorf("%s: precision %.2f is below %.2f", m.name, precision, m.minPrecision)
+		}
+		if recall < m.minRecall {
+			t.Errorf("%s: recall %.2f is below %.2f", m.name, recall, m.minRecall)
+		}
+	}
+
+	_ = w.Flush()
+	t.Logf("Matching report over %d title pairs:\n%s", len(pairs), report.String())
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
@@ -0,0 +1,221 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"fmt"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube/innertube"
+)
+
+// albumSearchResults is how many YouTube Music albums are opened while
+// looking for one which lines up with a Spotify Album
+const albumSearchResults = 3
+
+// Album describes a Spotify Album which is to be found on YouTube Music
+type Album struct {
+	SpotifyId string
+	Artist    string
+	Name      string
+	Tracks    []Track
+	// Songs is how many songs the Album has, counting those which are
+	// unavailable on Spotify and so have no Track. Zero means the Album has
+	// exactly its Tracks.
+	Songs int
+}
+
+// Query returns the text used to Search for the Album
+func (a Album) Query() string {
+	return fmt.Sprintf("%s %s", a.Artist, a.Name)
+}
+
+// songs is how many songs an album found for the Album must have
+func (a Album) songs() int {
+	if a.Songs > 0 {
+		return a.Songs
+	}
+	return len(a.Tracks)
+}
+
+// AlbumMatch is a YouTube Music album found for a Spotify Album. Matches
+// holds one entry per Spotify Track, in order, which is nil where the song at
+// the same position did not resemble the Track.
+type AlbumMatch struct {
+	Album      Album
+	BrowseId   string
+	PlaylistId string
+	Title      string
+	Matches    []*Match
+}
+
+// Verified reports whether every song of the album matched its Track, so that
+// the official album playlist can stand in for the Spotify Album.
+func (m *AlbumMatch) Verified() bool {
+	if m == nil || len(m.Matches) == 0 {
+		return false
+	}
+	for _, match := range m.Matches {
+		if match == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Match returns the Match for the Track at a position of the Album, or nil if
+// the song at that position did not match
+func (m *AlbumMatch) Match(idx int) *Match {
+	if m == nil || idx >= len(m.Matches) {
+		return nil
+	}
+	return m.Matches[idx]
+}
+
+// URL returns the YouTube Music page of the official album playlist
+func (m *AlbumMatch) URL() string {
+	return "https://music.youtube.com/playlist?list=" + m.PlaylistId
+}
+
+// FindAlbum searches YouTube Music for an Album, and lines the songs of the
+// first few albums found up against its Tracks. An album is only considered
+// if it has as many songs as the Album has Tracks. The album whose songs match
+// the most Tracks is returned, or nil if none had the right number of songs.
+func (yt *YouTube) FindAlbum(album Album) (*AlbumMatch, error) {
+	if yt.musicClient == nil {
+		return nil, nil
+	}
+
+	query := album.Query()
+	paramsTypeAlbum := innertube.MusicAlbumsParams
+
+	data, err := yt.musicClient.Search(&query, &paramsTypeAlbum, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error searching for album [%s]: %w", query, err)
+	}
+
+	results := innertube.ParseMusicAlbumSearch(data)
+	if len(results) > albumSearchResults {
+		results = results[:albumSearchResults]
+	}
+
+	var best *AlbumMatch
+	var bestCount int
+	for _, result := range results {
+		if score := yt.similarity.Similarity(album.Name, result.Title); score < yt.matchThreshold {
+			logger.Debug("Ignoring dissimilar album", "album", query, "title", result.Title, "score", score)
+			continue
+		}
+
+		browseId := result.BrowseId
+		page, err := yt.musicClient.Browse(&browseId, nil, nil)
+		if err != nil {
+			logger.Warn("Error retrieving album", "album", query, "browseId", browseId, "error", err)
+			continue
+		}
+
+		albumPage := innertube.ParseMusicAlbum(page)
+		if len(albumPage.Tracks) != album.songs() {
+			logger.Debug("Album has a different number of songs", "album", query, "browseId", browseId, "songs", len(albumPage.Tracks), "tracks", album.songs())
+			continue
+		}
+
+		match := yt.verifyAlbum(album, browseId, albumPage)
+		if match.Verified() {
+			logger.Info("Found album on YouTube Music", "album", query, "playlistId", match.PlaylistId)
+			return match, nil
+		}
+
+		count := 0
+		for _, trackMatch := range match.Matches {
+			if trackMatch != nil {
+				count++
+			}
+		}
+		if best == nil || count > bestCount {
+			best, bestCount = match, count
+		}
+	}
+
+	if best != nil {
+		logger.Info("Found album on YouTube Music, but not every song matched", "album", query, "playlistId", best.PlaylistId, "matched", bestCount, "tracks", len(album.Tracks))
+	}
+
+	return best, nil
+}
+
+// verifyAlbum compares each Track with the song at its Position on the album
+// page, or at the same index when the Position is not known, keeping a Match for the songs scoring at least the verify
+// threshold. Songs which are banned, or differ from the video pinned to their
+// Track, are not kept, so that the Track is searched for on its own.
+func (yt *YouTube) verifyAlbum(album Album, browseId string, page innertube.AlbumPage) *AlbumMatch {
+	match := &AlbumMatch{
+		Album:      album,
+		BrowseId:   browseId,
+		PlaylistId: page.PlaylistId,
+		Title:      page.Title,
+		Matches:    make([]*Match, len(album.Tracks)),
+	}
+
+	for idx, track := range album.Tracks {
+		position := idx
+		if track.Position > 0 {
+			position = track.Position - 1
+		}
+		if position >= len(page.Tracks) {
+			continue
+		}
+
+		song := page.Tracks[position]
+		if yt.overrides.Banned(song.VideoId, song.ChannelId) {
+			logger.Info("Ignoring banned video", "videoId", song.VideoId, "channel", song.Channel)
+			continue
+		}
+		if videoId, ok := yt.overrides.Pinned(track); ok && videoId != song.VideoId {
+			logger.Info("Ignoring album song, the Track is pinned to another video", "track", track.Query(), "videoId", song.VideoId, "pinned", videoId)
+			continue
+		}
+
+		score := yt.similarity.Similarity(track.Name, song.Title)
+		if length, ok := song.Duration(); ok {
+			weight, accepted := yt.duration.Weight(trackDuration(track), length)
+			if !accepted {
+				logger.Debug("Album song is the wrong length", "track", track.Query(), "title", song.Title, "length", song.Length)
+				continue
+			}
+			score *= weight
+		}
+		if score < yt.albumThreshold {
+			logger.Debug("Album song does not match the Track", "track", track.Query(), "title", song.Title, "score", score)
+			continue
+		}
+
+		match.Matches[idx] = &Match{
+			Track:  track,
+			Method: AlbumLookup,
+			Candidates: []Candidate{{
+				VideoId:   song.VideoId,
+				Title:     song.Title,
+				Channel:   song.Channel,
+				ChannelId: song.ChannelId,
+				Length:    song.Length,
+				Score:     score,
+			}},
+		}
+	}
+
+	return match
+}
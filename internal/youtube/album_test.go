@@ -0,0 +1,97 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"testing"
+)
+
+// discovery is the Spotify listing of Daft Punk's Discovery, which is recorded
+// in the YouTube Music fixtures
+func discovery() Album {
+	names := []string{
+		"One More Time", "Aerodynamic", "Digital Love", "Harder, Better, Faster, Stronger", "Crescendolls",
+		"Nightvision", "Superheroes", "High Life", "Something About Us", "Voyager", "Veridis Quo",
+		"Short Circuit", "Face to Face", "Too Long",
+	}
+
+	album := Album{SpotifyId: "2noRn2Aes5aoNVsU6iWThc", Artist: "Daft Punk", Name: "Discovery"}
+	for _, name := range names {
+		album.Tracks = append(album.Tracks, Track{Artist: "Daft Punk", Name: name, Album: "Discovery"})
+	}
+	return album
+}
+
+func TestFindAlbum(t *testing.T) {
+	yt := newReplayYouTube(t)
+
+	match, err := yt.FindAlbum(discovery())
+	if err != nil {
+		t.Fatalf("FindAlbum: %v", err)
+	}
+	if !match.Verified() {
+		t.Fatalf("expected a verified album, got %+v", match)
+	}
+	if match.PlaylistId != "OLAK5uy_discoveryDaftPunk0000000000000001" {
+		t.Errorf("unexpected album playlist [%s]", match.PlaylistId)
+	}
+
+	for idx, trackMatch := range match.Matches {
+		if trackMatch.Method != AlbumLookup {
+			t.Errorf("expected method [%s], got [%s]", AlbumLookup, trackMatch.Method)
+		}
+		if want := match.Album.Tracks[idx].Name; trackMatch.Best().Title != want {
+			t.Errorf("expected song [%d] to be [%s], got [%s]", idx+1, want, trackMatch.Best().Title)
+		}
+	}
+}
+
+func TestFindAlbumKeepsMatchingSongs(t *testing.T) {
+	yt := newReplayYouTube(t)
+
+	album := discovery()
+	album.Tracks[6].Name = "Something Else Entirely"
+
+	match, err := yt.FindAlbum(album)
+	if err != nil {
+		t.Fatalf("FindAlbum: %v", err)
+	}
+	if match == nil || match.Verified() {
+		t.Fatalf("expected a partly matched album, got %+v", match)
+	}
+	if match.Matches[6] != nil {
+		t.Errorf("expected the changed Track not to match, got %+v", match.Matches[6])
+	}
+	if match.Matches[5] == nil || match.Matches[7] == nil {
+		t.Errorf("expected the neighbouring Tracks to match")
+	}
+}
+
+func TestFindAlbumRejectsDifferentTrackCount(t *testing.T) {
+	yt := newReplayYouTube(t)
+
+	album := discovery()
+	album.Tracks = album.Tracks[:10]
+
+	match, err := yt.FindAlbum(album)
+	if err != nil {
+		t.Fatalf("FindAlbum: %v", err)
+	}
+	if match != nil {
+		t.Fatalf("expected no album, got %+v", match)
+	}
+}
@@ -20,14 +20,15 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
-	"log"
 	"net/http"
 	"os"
 
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
 	"github.com/pkg/browser"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 	"google.golang.org/api/youtube/v3"
 )
 
@@ -36,11 +37,12 @@ var googleAuthFile string
 
 var ch = make(chan string)
 
-func createYouTubeService() *youtube.Service {
-	ctx := context.Background()
+// createYouTubeService authorises with Google in the browser, and creates a
+// YouTube service. Any extra options are applied after the authorised client.
+func createYouTubeService(opts ...option.ClientOption) (*youtube.Service, *http.Client) {
 
 	if googleAuthFile == "" {
-		log.Fatal("Google Client Secret is blank. This binary was compiled incorrectly.")
+		logging.Fatal(logger, "Google Client Secret is blank. This binary was compiled incorrectly.")
 	}
 
 	// Read credentials from file
@@ -49,18 +51,37 @@ func createYouTubeService() *youtube.Service {
 	// Configure OAuth2 with required scopes
 	config, err := google.ConfigFromJSON(b, youtube.YoutubeForceSslScope)
 	if err != nil {
-		log.Fatalf("Unable to parse client secret file to config: %v", err)
+		logging.Fatal(logger, "Unable to parse client secret file to config", "error", err)
 	}
 
 	client := getClient(config)
 
 	// Create YouTube service
-	service, err := youtube.NewService(ctx, option.WithHTTPClient(client))
+	service, httpClient, err := newYouTubeService(append([]option.ClientOption{option.WithHTTPClient(client)}, opts...)...)
+	if err != nil {
+		logging.Fatal(logger, "Error creating YouTube service", "error", err)
+	}
+
+	return service, httpClient
+}
+
+// newYouTubeService creates a YouTube service, and returns the HTTP client it
+// sends requests with, for the batch and InnerTube requests which the client
+// library does not cover.
+func newYouTubeService(opts ...option.ClientOption) (*youtube.Service, *http.Client, error) {
+	ctx := context.Background()
+
+	httpClient, _, err := htransport.NewClient(ctx, append([]option.ClientOption{option.WithScopes(youtube.YoutubeForceSslScope)}, opts...)...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	service, err := youtube.NewService(ctx, append(opts, option.WithHTTPClient(httpClient))...)
 	if err != nil {
-		log.Fatalf("Error creating YouTube service: %v", err)
+		return nil, nil, err
 	}
 
-	return service
+	return service, httpClient, nil
 }
 
 // getClient retrieves a token, saves the token, and returns the configured client.
@@ -73,43 +94,43 @@ func getClient(config *oauth2.Config) *http.Client {
 func getTokenFromWeb(config *oauth2.Config) *oauth2.Token {
 	http.HandleFunc("/", completeAuth)
 	http.HandleFunc("/favicon.ico", func(w http.ResponseWriter, r *http.Request) {
-		log.Println("Got request for favicon.ico")
+		logger.Debug("Got request for favicon.ico")
 	})
 
 	go func() {
-		log.Println("Starting HTTP server")
+		logger.Debug("Starting HTTP server", "addr", ":8001")
 		err := http.ListenAndServe(":8001", nil)
 		if err != nil {
-			log.Fatal(err)
+			logging.Fatal(logger, "HTTP server failed", "error", err)
 		}
 	}()
 
 	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
-	log.Printf("Go to the following link in your browser:\n%v\n", authURL)
+	logger.Info("Go to the following link in your browser", "url", authURL)
 
 	if err := browser.OpenURL(authURL); err != nil {
-		log.Printf("Could not open browser automatically. Please visit the URL above to log in: Error: [%v]", err)
+		logger.Warn("Could not open browser automatically. Please visit the URL above to log in", "error", err)
 	}
 
 	authCode := <-ch
 
 	tok, err := config.Exchange(context.TODO(), authCode)
 	if err != nil {
-		log.Fatalf("Unable to retrieve token from web: %v", err)
+		logging.Fatal(logger, "Unable to retrieve token from web", "error", err)
 	}
 	return tok
 }
 
 func completeAuth(w http.ResponseWriter, r *http.Request) {
-	log.Println("Got request for:", r.URL.String())
+	logger.Debug("Got request", "url", logging.RedactURL(r.URL))
 
 	values := r.URL.Query()
 	if e := values.Get("error"); e != "" {
-		log.Fatalf(`return nil, errors.New("spotify: auth failed - " + e)`)
+		logging.Fatal(logger, "YouTube authorisation failed", "error", e)
 	}
 	code := values.Get("code")
 	if code == "" {
-		log.Fatalf(`return nil, errors.New("spotify: didn't get access code")`)
+		logging.Fatal(logger, "YouTube authorisation did not return an access code")
 	}
 
 	r.Context().Done()
@@ -131,10 +152,10 @@ func tokenFromFile(file string) (*oauth2.Token, error) {
 
 // saveToken saves a token to a file path.
 func saveToken(path string, token *oauth2.Token) {
-	log.Printf("Saving credential file to: %s\n", path)
+	logger.Info("Saving credential file", "path", path)
 	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
-		log.Fatalf("Unable to cache oauth token: %v", err)
+		logging.Fatal(logger, "Unable to cache oauth token", "error", err)
 	}
 	defer f.Close()
 	json.NewEncoder(f).Encode(token)
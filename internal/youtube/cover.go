@@ -0,0 +1,157 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+)
+
+const (
+	// coverWidth and coverHeight are the 16:9 size YouTube expects of a
+	// Playlist image
+	coverWidth  = 2048
+	coverHeight = 1152
+
+	playlistImagesListCost   = 1
+	playlistImagesInsertCost = 50
+)
+
+// CoverImage crops a JPEG or PNG image around its centre to the 16:9 shape of
+// a YouTube Playlist image, and scales it to coverWidth by coverHeight.
+// Spotify covers are square, so their top and bottom are cut off.
+func CoverImage(data []byte) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode cover image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	crop := bounds
+	if bounds.Dx()*coverHeight > bounds.Dy()*coverWidth {
+		width := bounds.Dy() * coverWidth / coverHeight
+		crop.Min.X += (bounds.Dx() - width) / 2
+		crop.Max.X = crop.Min.X + width
+	} else {
+		height := bounds.Dx() * coverHeight / coverWidth
+		crop.Min.Y += (bounds.Dy() - height) / 2
+		crop.Max.Y = crop.Min.Y + height
+	}
+	if crop.Empty() {
+		return nil, fmt.Errorf("cover image of size %v is too small", bounds.Size())
+	}
+
+	cropped := image.NewRGBA(image.Rect(0, 0, crop.Dx(), crop.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), src, crop.Min, draw.Src)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, scale(cropped, coverWidth, coverHeight), &jpeg.Options{Quality: 90}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// scale resizes an image with bilinear interpolation
+func scale(src *image.RGBA, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcW, srcH := src.Rect.Dx(), src.Rect.Dy()
+
+	for y := range height {
+		// Sample at the centre of each destination pixel
+		fy := max((float64(y)+0.5)*float64(srcH)/float64(height)-0.5, 0)
+		y0 := int(fy)
+		y1 := min(y0+1, srcH-1)
+		wy := fy - float64(y0)
+
+		for x := range width {
+			fx := max((float64(x)+0.5)*float64(srcW)/float64(width)-0.5, 0)
+			x0 := int(fx)
+			x1 := min(x0+1, srcW-1)
+			wx := fx - float64(x0)
+
+			for c := range 4 {
+				top := float64(src.Pix[src.PixOffset(x0, y0)+c])*(1-wx) + float64(src.Pix[src.PixOffset(x1, y0)+c])*wx
+				bottom := float64(src.Pix[src.PixOffset(x0, y1)+c])*(1-wx) + float64(src.Pix[src.PixOffset(x1, y1)+c])*wx
+				dst.Pix[dst.PixOffset(x, y)+c] = uint8(top*(1-wy) + bottom*wy + 0.5)
+			}
+		}
+	}
+
+	return dst
+}
+
+// SetPlaylistImage crops and scales an image with CoverImage, and uploads it
+// as the image of a Playlist, replacing any image it already has
+func (yt *YouTube) SetPlaylistImage(playlistId string, data []byte) error {
+	cover, err := CoverImage(data)
+	if err != nil {
+		return err
+	}
+
+	listCall := yt.client.PlaylistImages.List().Part("snippet").Parent(playlistId)
+
+	var existing *youtube.PlaylistImageListResponse
+	err = yt.do(func() (err error) {
+		existing, err = listCall.Do()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	yt.Credits += playlistImagesListCost
+
+	playlistImage := &youtube.PlaylistImage{
+		Snippet: &youtube.PlaylistImageSnippet{
+			PlaylistId: playlistId,
+			Type:       "hero",
+			Width:      coverWidth,
+			Height:     coverHeight,
+		},
+	}
+
+	// The upload is read once per attempt, so every attempt needs a new call
+	if len(existing.Items) > 0 {
+		playlistImage.Id = existing.Items[0].Id
+		err = yt.do(func() error {
+			_, err := yt.client.PlaylistImages.Update(playlistImage).Part("snippet").Media(bytes.NewReader(cover), googleapi.ContentType("image/jpeg")).Do()
+			return err
+		})
+	} else {
+		err = yt.doInsert(func() error {
+			_, err := yt.client.PlaylistImages.Insert(playlistImage).Part("snippet").Media(bytes.NewReader(cover), googleapi.ContentType("image/jpeg")).Do()
+			return err
+		}, func() bool {
+			images, err := listCall.Do()
+			yt.Credits += playlistImagesListCost
+			return err == nil && len(images.Items) > 0
+		})
+	}
+	if err != nil {
+		return err
+	}
+	yt.Credits += playlistImagesInsertCost
+
+	logger.Info("Set Playlist image", "playlistId", playlistId)
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/retry"
+	"google.golang.org/api/youtube/v3"
+)
+
+const playlistItemsDeleteCost = 50
+
+// unavailableTitles are the titles YouTube gives to videos which can no longer
+// be watched. They are not the same song, so are never grouped by title.
+var unavailableTitles = map[string]bool{
+	"Deleted video": true,
+	"Private video": true,
+}
+
+// DuplicateGroup is a video kept in a Playlist and the items which repeat it.
+// Exact groups only hold the same video more than once. Other groups also
+// hold different uploads whose titles match the kept video.
+type DuplicateGroup struct {
+	Keep   *youtube.PlaylistItem
+	Remove []*youtube.PlaylistItem
+	Exact  bool
+}
+
+// FindDuplicates groups the items of a Playlist which repeat an earlier item,
+// first by video ID, then by titles scoring at least threshold. A threshold of
+// zero only groups the same video. The earliest item of each group is kept.
+func (yt *YouTube) FindDuplicates(playlistId string, threshold float64) []DuplicateGroup {
+	var groups []*DuplicateGroup
+	var kept []*DuplicateGroup
+	byVideo := make(map[string]*DuplicateGroup)
+
+	for _, item := range yt.GetPlaylistItems(playlistId) {
+		videoId := item.Snippet.ResourceId.VideoId
+		if group, ok := byVideo[videoId]; ok {
+			group.Remove = append(group.Remove, item)
+			continue
+		}
+
+		group := &DuplicateGroup{Keep: item, Exact: true}
+		byVideo[videoId] = group
+		groups = append(groups, group)
+	}
+
+	for _, group := range groups {
+		if threshold <= 0 {
+			kept = append(kept, group)
+			continue
+		}
+		if match := yt.sameSong(kept, group.Keep, threshold); match != nil {
+			match.Exact = false
+			match.Remove = append(match.Remove, group.Keep)
+			match.Remove = append(match.Remove, group.Remove...)
+			continue
+		}
+		kept = append(kept, group)
+	}
+
+	var duplicates []DuplicateGroup
+	for _, group := range kept {
+		if len(group.Remove) > 0 {
+			duplicates = append(duplicates, *group)
+		}
+	}
+	return duplicates
+}
+
+// sameSong returns the first kept group whose video has a title matching the
+// item's, or nil if there is none
+func (yt *YouTube) sameSong(kept []*DuplicateGroup, item *youtube.PlaylistItem, threshold float64) *DuplicateGroup {
+	title := item.Snippet.Title
+	if unavailableTitles[title] {
+		return nil
+	}
+
+	for _, group := range kept {
+		keptTitle := group.Keep.Snippet.Title
+		if unavailableTitles[keptTitle] {
+			continue
+		}
+		if yt.similarity.Similarity(keptTitle, title) >= threshold {
+			return group
+		}
+	}
+	return nil
+}
+
+// WriteDuplicatePlan writes the items each group keeps and removes, for the
+// terminal
+func WriteDuplicatePlan(w io.Writer, groups []DuplicateGroup) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ACTION\tPOSITION\tVIDEO\tTITLE\tMATCH")
+	removals := 0
+	for _, group := range groups {
+		keptId := group.Keep.Snippet.ResourceId.VideoId
+		fmt.Fprintf(tw, "keep\t%d\t%s\t%s\t\n", group.Keep.Snippet.Position+1, keptId, group.Keep.Snippet.Title)
+		for _, item := range group.Remove {
+			match := "similar title"
+			if item.Snippet.ResourceId.VideoId == keptId {
+				match = "same video"
+			}
+			fmt.Fprintf(tw, "remove\t%d\t%s\t%s\t%s\n", item.Snippet.Position+1, item.Snippet.ResourceId.VideoId, item.Snippet.Title, match)
+		}
+		removals += len(group.Remove)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "%d duplicates to remove. Credits: %d\n", removals, removals*playlistItemsDeleteCost)
+	return err
+}
+
+// RemoveDuplicates deletes the items each group removes, returning how many
+// were deleted. Items which are already gone are skipped, but a terminal
+// error stops the removal and is returned.
+func (yt *YouTube) RemoveDuplicates(groups []DuplicateGroup) (int, error) {
+	removed := 0
+	for _, group := range groups {
+		for _, item := range group.Remove {
+			err := yt.do(func() error {
+				return yt.client.PlaylistItems.Delete(item.Id).Do()
+			})
+			if err != nil {
+				if retry.Classify(err) == retry.Skip {
+					logger.Warn("Skipping duplicate which cannot be removed", "itemId", item.Id, "videoId", item.Snippet.ResourceId.VideoId, "error", err)
+					continue
+				}
+
+				logger.Error("Error removing duplicate from Playlist", "itemId", item.Id, "videoId", item.Snippet.ResourceId.VideoId, "error", err)
+				return removed, err
+			}
+			yt.Credits += playlistItemsDeleteCost
+			removed++
+
+			logger.Info("Removed duplicate from Playlist", "videoId", item.Snippet.ResourceId.VideoId, "title", item.Snippet.Title, "playlistId", item.Snippet.PlaylistId)
+		}
+	}
+
+	return removed, nil
+}
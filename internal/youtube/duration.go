@@ -0,0 +1,97 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// DurationWindow decides whether a video is a plausible length for a Track.
+// Videos within Tolerance of the Track keep their score, those further out are
+// penalised increasingly, and those more than Reject away are rejected.
+type DurationWindow struct {
+	Tolerance time.Duration
+	// Reject is the largest difference accepted. Zero disables the check.
+	Reject time.Duration
+}
+
+// maxDurationPenalty is the fraction of the score lost by a video just inside
+// the Reject limit
+const maxDurationPenalty = 0.5
+
+// Weight returns the factor to multiply a Candidate's score by, and false if
+// the video should be rejected. Unknown durations are neither penalised nor
+// rejected.
+func (w DurationWindow) Weight(track, video time.Duration) (float64, bool) {
+	if w.Reject <= 0 || track <= 0 || video <= 0 {
+		return 1, true
+	}
+
+	difference := (track - video).Abs()
+	switch {
+	case difference <= w.Tolerance:
+		return 1, true
+	case difference > w.Reject:
+		return 0, false
+	}
+
+	return 1 - maxDurationPenalty*float64(difference-w.Tolerance)/float64(w.Reject-w.Tolerance), true
+}
+
+var isoDuration = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// ParseISODuration parses the ISO 8601 durations returned in video
+// contentDetails, such as "PT4M13S".
+func ParseISODuration(value string) (time.Duration, bool) {
+	parts := isoDuration.FindStringSubmatch(value)
+	if parts == nil || value == "P" || value == "PT" {
+		return 0, false
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var duration time.Duration
+	for idx, unit := range units {
+		if parts[idx+1] == "" {
+			continue
+		}
+		count, err := strconv.Atoi(parts[idx+1])
+		if err != nil {
+			return 0, false
+		}
+		duration += time.Duration(count) * unit
+	}
+
+	return duration, true
+}
+
+// trackDuration is the length of the Track, or zero if it is not known
+func trackDuration(track Track) time.Duration {
+	return time.Duration(track.Duration) * time.Millisecond
+}
+
+// FormatLength displays a video length as YouTube does, such as "4:13" or
+// "1:02:03". It is the reverse of innertube.ParseLength.
+func FormatLength(length time.Duration) string {
+	seconds := int(length.Round(time.Second) / time.Second)
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,79 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDurationWindowWeight(t *testing.T) {
+	window := DurationWindow{Tolerance: 15 * time.Second, Reject: 90 * time.Second}
+	track := 3 * time.Minute
+
+	tests := []struct {
+		video    time.Duration
+		weight   float64
+		accepted bool
+	}{
+		{track, 1, true},
+		{track + 15*time.Second, 1, true},
+		{track - 15*time.Second, 1, true},
+		{track + 90*time.Second, 0.5, true},
+		{track - 52500*time.Millisecond, 0.75, true},
+		{time.Hour, 0, false},
+		{30 * time.Second, 0, false},
+		{0, 1, true},
+	}
+
+	for _, test := range tests {
+		weight, accepted := window.Weight(track, test.video)
+		if accepted != test.accepted || math.Abs(weight-test.weight) > 0.0001 {
+			t.Errorf("Weight(%v, %v) = %v, %v, want %v, %v", track, test.video, weight, accepted, test.weight, test.accepted)
+		}
+	}
+
+	if weight, accepted := window.Weight(0, time.Hour); weight != 1 || !accepted {
+		t.Error("expected a Track of unknown length to accept any video")
+	}
+	if weight, accepted := (DurationWindow{}).Weight(track, time.Hour); weight != 1 || !accepted {
+		t.Error("expected a disabled window to accept any video")
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT4M13S":  4*time.Minute + 13*time.Second,
+		"PT1H":     time.Hour,
+		"PT1H0M5S": time.Hour + 5*time.Second,
+		"PT45S":    45 * time.Second,
+		"P1DT2H":   26 * time.Hour,
+		"PT0S":     0,
+	}
+	for value, want := range tests {
+		if got, ok := ParseISODuration(value); !ok || got != want {
+			t.Errorf("ParseISODuration(%q) = %v, %v, want %v", value, got, ok, want)
+		}
+	}
+
+	for _, value := range []string{"", "P", "PT", "4:13", "PT4X"} {
+		if got, ok := ParseISODuration(value); ok {
+			t.Errorf("ParseISODuration(%q) = %v, expected it to fail", value, got)
+		}
+	}
+}
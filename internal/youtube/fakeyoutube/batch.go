@@ -0,0 +1,155 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package fakeyoutube
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube/innertube"
+)
+
+// batch answers a multipart/mixed batch request, handling each part as if it
+// had been sent on its own. Each part is charged and counted as its method.
+// A failure set for "batch" fails the whole request.
+func (s *Server) batch(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.calls["batch"]++
+	pending := s.failures["batch"]
+	if len(pending) > 0 {
+		s.failures["batch"] = pending[1:]
+	}
+	s.mu.Unlock()
+
+	if len(pending) > 0 {
+		if pending[0].applied {
+			s.answerBatch(httptest.NewRecorder(), r)
+		}
+		writeError(w, pending[0].status, pending[0].reason)
+		return
+	}
+
+	s.answerBatch(w, r)
+}
+
+// answerBatch carries out each part of a batch request
+func (s *Server) answerBatch(w http.ResponseWriter, r *http.Request) {
+
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		writeError(w, http.StatusBadRequest, "badContent")
+		return
+	}
+
+	// The response is buffered, as the request body cannot be read once the
+	// response has started
+	var body bytes.Buffer
+	reader := multipart.NewReader(r.Body, params["boundary"])
+	writer := multipart.NewWriter(&body)
+
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+
+		request, err := http.ReadRequest(bufio.NewReader(part))
+		if err != nil {
+			continue
+		}
+
+		recorder := httptest.NewRecorder()
+		s.handle(recorder, request)
+
+		contentId := strings.Trim(part.Header.Get("Content-Id"), "<>")
+		out, _ := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {"application/http"},
+			"Content-Id":   {fmt.Sprintf("<response-%s>", contentId)},
+		})
+		_ = recorder.Result().Write(out)
+	}
+
+	_ = writer.Close()
+
+	w.Header().Set("Content-Type", "multipart/mixed; boundary="+writer.Boundary())
+	_, _ = w.Write(body.Bytes())
+}
+
+// InnerTube returns an InnerTube client which adds videos to the Playlists
+// of the Server through browse/edit_playlist, without charging quota
+func (s *Server) InnerTube() *innertube.InnerTube {
+	return &innertube.InnerTube{Adaptor: &editAdaptor{server: s}}
+}
+
+type editAdaptor struct {
+	server *Server
+}
+
+// Dispatch adds every video in the request, or none of them if the Playlist
+// is unknown or any video is unavailable
+func (a *editAdaptor) Dispatch(endpoint string, _ map[string]string, body map[string]interface{}) (map[string]interface{}, error) {
+	s := a.server
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls["innertube.editPlaylist"]++
+	if endpoint != "BROWSE/EDIT_PLAYLIST" {
+		return nil, fmt.Errorf("unsupported InnerTube endpoint [%s]", endpoint)
+	}
+
+	var failure *failure
+	if pending := s.failures["innertube.editPlaylist"]; len(pending) > 0 {
+		s.failures["innertube.editPlaylist"] = pending[1:]
+		failure = &pending[0]
+		if !failure.applied {
+			return nil, failure.err()
+		}
+	}
+
+	failed := map[string]interface{}{"status": "STATUS_FAILED"}
+
+	playlistId, _ := body["playlistId"].(string)
+	if s.findPlaylist(playlistId) == nil {
+		return failed, nil
+	}
+
+	actions, _ := body["actions"].([]interface{})
+	var videoIds []string
+	for _, action := range actions {
+		videoId, _ := action.(map[string]interface{})["addedVideoId"].(string)
+		if s.unavailable[videoId] {
+			return failed, nil
+		}
+		videoIds = append(videoIds, videoId)
+	}
+
+	for _, videoId := range videoIds {
+		s.insertPlaylistItem(playlistId, videoId, s.videoTitle(videoId))
+	}
+	if failure != nil {
+		return nil, failure.err()
+	}
+
+	return map[string]interface{}{"status": "STATUS_SUCCEEDED"}, nil
+}
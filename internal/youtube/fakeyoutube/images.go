@@ -0,0 +1,84 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package fakeyoutube
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+func (s *Server) listPlaylistImages(w http.ResponseWriter, r *http.Request) {
+	playlistId := r.URL.Query().Get("parent")
+
+	var items []*youtube.PlaylistImage
+	if _, ok := s.images[playlistId]; ok {
+		items = append(items, playlistImage(playlistId))
+	}
+
+	writeJSON(w, &youtube.PlaylistImageListResponse{Kind: "youtube#playlistImageListResponse", Items: items})
+}
+
+// putPlaylistImage stores an image uploaded as multipart/related, with the
+// PlaylistImage in the first part and the image in the second
+func (s *Server) putPlaylistImage(w http.ResponseWriter, r *http.Request) {
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || params["boundary"] == "" {
+		writeError(w, http.StatusBadRequest, "mediaBodyRequired")
+		return
+	}
+	reader := multipart.NewReader(r.Body, params["boundary"])
+
+	metadata := &youtube.PlaylistImage{}
+	part, err := reader.NextPart()
+	if err != nil || json.NewDecoder(part).Decode(metadata) != nil || metadata.Snippet == nil {
+		writeError(w, http.StatusBadRequest, "invalidPlaylistImage")
+		return
+	}
+
+	part, err = reader.NextPart()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "mediaBodyRequired")
+		return
+	}
+	image, err := io.ReadAll(part)
+	if err != nil || len(image) == 0 {
+		writeError(w, http.StatusBadRequest, "mediaBodyRequired")
+		return
+	}
+
+	playlistId := metadata.Snippet.PlaylistId
+	if s.findPlaylist(playlistId) == nil {
+		writeError(w, http.StatusNotFound, "playlistNotFound")
+		return
+	}
+
+	s.images[playlistId] = image
+	writeJSON(w, playlistImage(playlistId))
+}
+
+func playlistImage(playlistId string) *youtube.PlaylistImage {
+	return &youtube.PlaylistImage{
+		Kind:    "youtube#playlistImage",
+		Id:      playlistId + "-hero",
+		Snippet: &youtube.PlaylistImageSnippet{PlaylistId: playlistId, Type: "hero"},
+	}
+}
@@ -0,0 +1,568 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+// Package fakeyoutube is an in-memory fake of the YouTube Data API v3
+// endpoints used by the converter, for testing without network access or
+// quota.
+package fakeyoutube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/youtube/v3"
+)
+
+// Quota cost of each method, in units
+var costs = map[string]int{
+	"channels.list":         1,
+	"playlists.list":        1,
+	"playlists.insert":      50,
+	"playlists.update":      50,
+	"playlistImages.list":   1,
+	"playlistImages.insert": 50,
+	"playlistImages.update": 50,
+	"playlistItems.list":    1,
+	"playlistItems.insert":  50,
+	"playlistItems.delete":  50,
+	"search.list":           100,
+	"videos.list":           1,
+}
+
+const defaultPageSize = 5
+
+type failure struct {
+	status int
+	reason string
+	// applied failures carry out the call before reporting the error, like a
+	// timeout after the server has committed
+	applied bool
+}
+
+// err is the error an InnerTube request fails with
+func (f *failure) err() error {
+	return fmt.Errorf("InnerTube request failed with status [%d]: %s", f.status, f.reason)
+}
+
+// Server is a fake YouTube Data API. Create one with New, and point a
+// youtube.Service at it with option.WithEndpoint(server.URL()).
+type Server struct {
+	server *httptest.Server
+
+	mu          sync.Mutex
+	channelId   string
+	playlists   []*youtube.Playlist
+	items       map[string][]*youtube.PlaylistItem
+	images      map[string][]byte
+	videos      []*youtube.SearchResult
+	durations   map[string]time.Duration
+	unavailable map[string]bool
+	failures    map[string][]failure
+	calls       map[string]int
+	quotaUsed   int
+	nextId      int
+
+	// QuotaLimit is the number of units available before every call fails with
+	// quotaExceeded. Zero means unlimited.
+	QuotaLimit int
+}
+
+// New starts a fake Server with a single channel and no Playlists
+func New() *Server {
+	s := &Server{
+		channelId:   "UCfakechannel0000000000",
+		items:       make(map[string][]*youtube.PlaylistItem),
+		images:      make(map[string][]byte),
+		durations:   make(map[string]time.Duration),
+		unavailable: make(map[string]bool),
+		failures:    make(map[string][]failure),
+		calls:       make(map[string]int),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// URL is the endpoint to pass to option.WithEndpoint
+func (s *Server) URL() string {
+	return s.server.URL + "/"
+}
+
+// Close shuts the Server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// AddPlaylist creates a Playlist owned by the channel, as if made outside the
+// converter.
+func (s *Server) AddPlaylist(title string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.insertPlaylist(&youtube.Playlist{Snippet: &youtube.PlaylistSnippet{Title: title}}).Id
+}
+
+// AddPlaylistItem appends a video to a Playlist
+func (s *Server) AddPlaylistItem(playlistId, videoId, title string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.insertPlaylistItem(playlistId, videoId, title)
+}
+
+// AddVideo adds a video to the search corpus
+func (s *Server) AddVideo(videoId, title, channelId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.videos = append(s.videos, &youtube.SearchResult{
+		Kind: "youtube#searchResult",
+		Id:   &youtube.ResourceId{Kind: "youtube#video", VideoId: videoId},
+		Snippet: &youtube.SearchResultSnippet{
+			Title:     title,
+			ChannelId: channelId,
+		},
+	})
+}
+
+// SetDuration sets the length of a video returned by videos.list
+func (s *Server) SetDuration(videoId string, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.durations[videoId] = duration
+}
+
+// MakeUnavailable causes inserts of the video to fail with videoNotFound
+func (s *Server) MakeUnavailable(videoId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unavailable[videoId] = true
+}
+
+// Fail makes the next n calls to method (e.g. "playlistItems.insert") fail
+// with the given HTTP status and error reason. The method "batch" fails a
+// whole batch request, and "innertube.editPlaylist" an InnerTube request.
+func (s *Server) Fail(method string, status int, reason string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for range n {
+		s.failures[method] = append(s.failures[method], failure{status: status, reason: reason})
+	}
+}
+
+// FailAfterApplying makes the next n calls to method take effect, but still
+// fail with the given HTTP status and error reason
+func (s *Server) FailAfterApplying(method string, status int, reason string, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for range n {
+		s.failures[method] = append(s.failures[method], failure{status: status, reason: reason, applied: true})
+	}
+}
+
+// Playlists returns a copy of every Playlist
+func (s *Server) Playlists() []youtube.Playlist {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	playlists := make([]youtube.Playlist, 0, len(s.playlists))
+	for _, playlist := range s.playlists {
+		playlists = append(playlists, *playlist)
+	}
+	return playlists
+}
+
+// PlaylistVideoIds returns the Video IDs in a Playlist, in order
+func (s *Server) PlaylistVideoIds(playlistId string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var videoIds []string
+	for _, item := range s.items[playlistId] {
+		videoIds = append(videoIds, item.Snippet.ResourceId.VideoId)
+	}
+	return videoIds
+}
+
+// PlaylistImage returns the image uploaded to a Playlist, or nil if it has none
+func (s *Server) PlaylistImage(playlistId string) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.images[playlistId]
+}
+
+// QuotaUsed returns the quota units charged so far
+func (s *Server) QuotaUsed() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.quotaUsed
+}
+
+// Calls returns how many times method has been called, including failures
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls[method]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/batch/youtube/v3" {
+		s.batch(w, r)
+		return
+	}
+
+	resource := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/upload"), "/youtube/v3/")
+
+	var verb string
+	switch r.Method {
+	case http.MethodGet:
+		verb = "list"
+	case http.MethodPost:
+		verb = "insert"
+	case http.MethodPut:
+		verb = "update"
+	case http.MethodDelete:
+		verb = "delete"
+	}
+	method := resource + "." + verb
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls[method]++
+
+	if pending := s.failures[method]; len(pending) > 0 {
+		s.failures[method] = pending[1:]
+		if pending[0].applied {
+			s.dispatch(httptest.NewRecorder(), r, method)
+		}
+		writeError(w, pending[0].status, pending[0].reason)
+		return
+	}
+
+	cost, ok := costs[method]
+	if !ok {
+		writeError(w, http.StatusNotFound, "notFound")
+		return
+	}
+
+	if s.QuotaLimit > 0 && s.quotaUsed+cost > s.QuotaLimit {
+		writeError(w, http.StatusForbidden, "quotaExceeded")
+		return
+	}
+	s.quotaUsed += cost
+
+	s.dispatch(w, r, method)
+}
+
+// dispatch carries out a call
+func (s *Server) dispatch(w http.ResponseWriter, r *http.Request, method string) {
+	switch method {
+	case "channels.list":
+		s.listChannels(w)
+	case "playlists.list":
+		s.listPlaylists(w, r)
+	case "playlists.insert":
+		s.postPlaylist(w, r)
+	case "playlists.update":
+		s.putPlaylist(w, r)
+	case "playlistImages.list":
+		s.listPlaylistImages(w, r)
+	case "playlistImages.insert", "playlistImages.update":
+		s.putPlaylistImage(w, r)
+	case "playlistItems.list":
+		s.listPlaylistItems(w, r)
+	case "playlistItems.insert":
+		s.postPlaylistItem(w, r)
+	case "playlistItems.delete":
+		s.deletePlaylistItem(w, r)
+	case "search.list":
+		s.search(w, r)
+	case "videos.list":
+		s.listVideos(w, r)
+	}
+}
+
+func (s *Server) listChannels(w http.ResponseWriter) {
+	writeJSON(w, &youtube.ChannelListResponse{
+		Kind: "youtube#channelListResponse",
+		Items: []*youtube.Channel{{
+			Kind:    "youtube#channel",
+			Id:      s.channelId,
+			Snippet: &youtube.ChannelSnippet{Title: "Fake Channel"},
+		}},
+	})
+}
+
+func (s *Server) listPlaylists(w http.ResponseWriter, r *http.Request) {
+	start, end, next := page(r, len(s.playlists))
+
+	writeJSON(w, &youtube.PlaylistListResponse{
+		Kind:          "youtube#playlistListResponse",
+		Items:         s.playlists[start:end],
+		NextPageToken: next,
+		PageInfo:      &youtube.PageInfo{TotalResults: int64(len(s.playlists))},
+	})
+}
+
+func (s *Server) postPlaylist(w http.ResponseWriter, r *http.Request) {
+	playlist := &youtube.Playlist{}
+	if err := json.NewDecoder(r.Body).Decode(playlist); err != nil || playlist.Snippet == nil || playlist.Snippet.Title == "" {
+		writeError(w, http.StatusBadRequest, "playlistTitleRequired")
+		return
+	}
+
+	writeJSON(w, s.insertPlaylist(playlist))
+}
+
+func (s *Server) putPlaylist(w http.ResponseWriter, r *http.Request) {
+	update := &youtube.Playlist{}
+	if err := json.NewDecoder(r.Body).Decode(update); err != nil || update.Snippet == nil || update.Snippet.Title == "" {
+		writeError(w, http.StatusBadRequest, "playlistTitleRequired")
+		return
+	}
+
+	playlist := s.findPlaylist(update.Id)
+	if playlist == nil {
+		writeError(w, http.StatusNotFound, "playlistNotFound")
+		return
+	}
+
+	update.Snippet.ChannelId = playlist.Snippet.ChannelId
+	update.Snippet.PublishedAt = playlist.Snippet.PublishedAt
+	playlist.Snippet = update.Snippet
+
+	writeJSON(w, playlist)
+}
+
+func (s *Server) listPlaylistItems(w http.ResponseWriter, r *http.Request) {
+	playlistId := r.URL.Query().Get("playlistId")
+	if s.findPlaylist(playlistId) == nil {
+		writeError(w, http.StatusNotFound, "playlistNotFound")
+		return
+	}
+
+	items := s.items[playlistId]
+	start, end, next := page(r, len(items))
+
+	writeJSON(w, &youtube.PlaylistItemListResponse{
+		Kind:          "youtube#playlistItemListResponse",
+		Items:         items[start:end],
+		NextPageToken: next,
+		PageInfo:      &youtube.PageInfo{TotalResults: int64(len(items))},
+	})
+}
+
+func (s *Server) postPlaylistItem(w http.ResponseWriter, r *http.Request) {
+	item := &youtube.PlaylistItem{}
+	if err := json.NewDecoder(r.Body).Decode(item); err != nil || item.Snippet == nil || item.Snippet.ResourceId == nil {
+		writeError(w, http.StatusBadRequest, "invalidResourceId")
+		return
+	}
+
+	playlistId := item.Snippet.PlaylistId
+	videoId := item.Snippet.ResourceId.VideoId
+
+	if s.findPlaylist(playlistId) == nil {
+		writeError(w, http.StatusNotFound, "playlistNotFound")
+		return
+	}
+	if s.unavailable[videoId] {
+		writeError(w, http.StatusNotFound, "videoNotFound")
+		return
+	}
+
+	writeJSON(w, s.insertPlaylistItem(playlistId, videoId, s.videoTitle(videoId)))
+}
+
+func (s *Server) deletePlaylistItem(w http.ResponseWriter, r *http.Request) {
+	itemId := r.URL.Query().Get("id")
+
+	for playlistId, items := range s.items {
+		for idx, item := range items {
+			if item.Id == itemId {
+				s.items[playlistId] = append(items[:idx:idx], items[idx+1:]...)
+				s.renumber(playlistId)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "playlistItemNotFound")
+}
+
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	terms := strings.Fields(strings.ToLower(r.URL.Query().Get("q")))
+
+	var results []*youtube.SearchResult
+	for _, video := range s.videos {
+		title := strings.ToLower(video.Snippet.Title)
+		for _, term := range terms {
+			if strings.Contains(title, term) {
+				results = append(results, video)
+				break
+			}
+		}
+	}
+
+	start, end, next := page(r, len(results))
+
+	writeJSON(w, &youtube.SearchListResponse{
+		Kind:          "youtube#searchListResponse",
+		Items:         results[start:end],
+		NextPageToken: next,
+		PageInfo:      &youtube.PageInfo{TotalResults: int64(len(results))},
+	})
+}
+
+func (s *Server) listVideos(w http.ResponseWriter, r *http.Request) {
+	var videoIds []string
+	for _, ids := range r.URL.Query()["id"] {
+		videoIds = append(videoIds, strings.Split(ids, ",")...)
+	}
+
+	var videos []*youtube.Video
+	for _, videoId := range videoIds {
+		duration, ok := s.durations[videoId]
+		if !ok {
+			continue
+		}
+
+		total := int(duration.Seconds())
+		videos = append(videos, &youtube.Video{
+			Kind: "youtube#video",
+			Id:   videoId,
+			ContentDetails: &youtube.VideoContentDetails{
+				Duration: fmt.Sprintf("PT%dH%dM%dS", total/3600, total%3600/60, total%60),
+			},
+		})
+	}
+
+	writeJSON(w, &youtube.VideoListResponse{Kind: "youtube#videoListResponse", Items: videos})
+}
+
+func (s *Server) insertPlaylist(playlist *youtube.Playlist) *youtube.Playlist {
+	s.nextId++
+	playlist.Kind = "youtube#playlist"
+	playlist.Id = fmt.Sprintf("PLfake%04d", s.nextId)
+	playlist.Snippet.ChannelId = s.channelId
+	playlist.Snippet.PublishedAt = time.Now().UTC().Format(time.RFC3339)
+	playlist.ContentDetails = &youtube.PlaylistContentDetails{}
+
+	s.playlists = append(s.playlists, playlist)
+	return playlist
+}
+
+func (s *Server) insertPlaylistItem(playlistId, videoId, title string) *youtube.PlaylistItem {
+	s.nextId++
+	item := &youtube.PlaylistItem{
+		Kind: "youtube#playlistItem",
+		Id:   fmt.Sprintf("PLIfake%04d", s.nextId),
+		Snippet: &youtube.PlaylistItemSnippet{
+			PlaylistId: playlistId,
+			Title:      title,
+			ResourceId: &youtube.ResourceId{Kind: "youtube#video", VideoId: videoId},
+		},
+		ContentDetails: &youtube.PlaylistItemContentDetails{VideoId: videoId},
+	}
+
+	s.items[playlistId] = append(s.items[playlistId], item)
+	s.renumber(playlistId)
+	return item
+}
+
+func (s *Server) renumber(playlistId string) {
+	for idx, item := range s.items[playlistId] {
+		item.Snippet.Position = int64(idx)
+	}
+	if playlist := s.findPlaylist(playlistId); playlist != nil {
+		playlist.ContentDetails.ItemCount = int64(len(s.items[playlistId]))
+	}
+}
+
+func (s *Server) findPlaylist(playlistId string) *youtube.Playlist {
+	for _, playlist := range s.playlists {
+		if playlist.Id == playlistId {
+			return playlist
+		}
+	}
+	return nil
+}
+
+func (s *Server) videoTitle(videoId string) string {
+	for _, video := range s.videos {
+		if video.Id.VideoId == videoId {
+			return video.Snippet.Title
+		}
+	}
+	return videoId
+}
+
+// page returns the bounds of the requested page, and the token of the next
+func page(r *http.Request, total int) (int, int, string) {
+	size := defaultPageSize
+	if maxResults, err := strconv.Atoi(r.URL.Query().Get("maxResults")); err == nil && maxResults > 0 {
+		size = maxResults
+	}
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("pageToken"))
+	start = min(max(start, 0), total)
+	end := min(start+size, total)
+
+	next := ""
+	if end < total {
+		next = strconv.Itoa(end)
+	}
+
+	return start, end, next
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, reason string) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+
+	message := fmt.Sprintf("fake failure: %s", reason)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    status,
+			"message": message,
+			"errors": []map[string]string{{
+				"domain":  "youtube",
+				"reason":  reason,
+				"message": message,
+			}},
+		},
+	})
+}
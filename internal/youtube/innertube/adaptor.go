@@ -25,17 +25,24 @@ package innertube
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/retry"
 )
 
+var logger = logging.For("innertube")
+
 type InnerTubeAdaptor struct {
-	context ClientContext
-	session *http.Client
+	context     ClientContext
+	session     *http.Client
+	retryPolicy retry.Policy
 }
 
 func NewInnerTubeAdaptor(context ClientContext, session *http.Client) *InnerTubeAdaptor {
@@ -43,8 +50,9 @@ func NewInnerTubeAdaptor(context ClientContext, session *http.Client) *InnerTube
 		session = &http.Client{}
 	}
 	return &InnerTubeAdaptor{
-		context: context,
-		session: session,
+		context:     context,
+		session:     session,
+		retryPolicy: retry.DefaultPolicy,
 	}
 }
 
@@ -73,35 +81,49 @@ func (ita *InnerTubeAdaptor) buildRequest(endpoint string, params map[string]str
 
 func (ita *InnerTubeAdaptor) request(endpoint string, params map[string]string, body map[string]interface{}) (*http.Response, error) {
 	req, err := ita.buildRequest(endpoint, params, body)
-
-	log.Println("Method: ", req.Method)
-	log.Println("URL: ", req.URL)
-	log.Println("Request Headers: ")
-	for k, v := range req.Header {
-		log.Println(fmt.Sprintf("%s: ", k), v)
-	}
-	log.Println("Header: ", req.Header)
-	log.Println("Cookies: ", req.Cookies())
-	log.Println("UserAgent: ", req.UserAgent())
-	log.Println("Form: ", req.Form)
-	log.Println("PostForm", req.PostForm)
-	log.Println("Body: ", req.Body)
-
 	if err != nil {
 		return nil, err
 	}
+
+	if logger.Enabled(context.Background(), slog.LevelDebug) {
+		logger.Debug("InnerTube request",
+			"method", req.Method,
+			"url", logging.RedactURL(req.URL),
+			"headers", logging.RedactHeaders(req.Header),
+		)
+	}
+
 	return ita.session.Do(req)
 }
 
+// Dispatch sends the request, retrying transient failures according to the
+// retry Policy of the Adaptor.
 func (ita *InnerTubeAdaptor) Dispatch(endpoint string, params map[string]string, body map[string]interface{}) (map[string]interface{}, error) {
+	var responseData map[string]interface{}
+
+	err := retry.Do(ita.retryPolicy, func() error {
+		var err error
+		responseData, err = ita.dispatch(endpoint, params, body)
+		return err
+	})
+
+	return responseData, err
+}
+
+func (ita *InnerTubeAdaptor) dispatch(endpoint string, params map[string]string, body map[string]interface{}) (map[string]interface{}, error) {
 	resp, err := ita.request(endpoint, params, body)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	bodyResp, err := readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
 	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("request failed with status code [%d]. Status [%s]", resp.StatusCode, resp.Status)
+		return nil, newError(resp, bodyResp)
 	}
 
 	contentType := resp.Header.Get("Content-Type")
@@ -109,25 +131,8 @@ func (ita *InnerTubeAdaptor) Dispatch(endpoint string, params map[string]string,
 		return nil, fmt.Errorf("expected JSON response, got %q", contentType)
 	}
 
-	bodyResp, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var reader io.Reader
-	if resp.Header.Get("Content-Encoding") == "gzip" {
-		gzr, err := gzip.NewReader(bytes.NewReader(bodyResp))
-		if err != nil {
-			return nil, err
-		}
-		defer gzr.Close()
-		reader = gzr
-	} else {
-		reader = bytes.NewReader(bodyResp)
-	}
-
 	var responseData map[string]interface{}
-	if err := json.NewDecoder(reader).Decode(&responseData); err != nil {
+	if err := json.Unmarshal(bodyResp, &responseData); err != nil {
 		return nil, err
 	}
 
@@ -138,13 +143,65 @@ func (ita *InnerTubeAdaptor) Dispatch(endpoint string, params map[string]string,
 		}
 	}
 
-	if errorData, ok := responseData["error"]; ok {
-		return nil, fmt.Errorf("request error: %v", errorData)
+	if _, ok := responseData["error"]; ok {
+		return nil, newError(resp, bodyResp)
 	}
 
 	return responseData, nil
 }
 
+// readBody reads the whole Response Body, decompressing it if required
+func readBody(resp *http.Response) ([]byte, error) {
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return io.ReadAll(resp.Body)
+	}
+
+	gzr, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer gzr.Close()
+
+	return io.ReadAll(gzr)
+}
+
+// newError builds an Error from a failed Response, using the Google API error
+// envelope in the body when one is present.
+func newError(resp *http.Response, body []byte) *Error {
+	e := &Error{
+		code:       resp.StatusCode,
+		message:    resp.Status,
+		retryAfter: retry.ParseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+
+	var envelope struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Status  string `json:"status"`
+			Errors  []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return e
+	}
+
+	if envelope.Error.Code != 0 {
+		e.code = envelope.Error.Code
+	}
+	if envelope.Error.Message != "" {
+		e.message = envelope.Error.Message
+	}
+	e.status = envelope.Error.Status
+	if len(envelope.Error.Errors) > 0 {
+		e.reason = envelope.Error.Errors[0].Reason
+	}
+
+	return e
+}
+
 func isJSONContentType(contentType string) bool {
 	return contentType == "application/json" || contentType == "application/json; charset=utf-8" || contentType == "application/json; charset=UTF-8"
 }
@@ -38,10 +38,28 @@ type Adaptor interface {
 
 // NewInnerTube creates a new InnerTube instance
 func NewInnerTube() (*InnerTube, error) {
-	context := GetContext("WEB")
+	return NewInnerTubeWithClient(&http.Client{})
+}
+
+// NewInnerTubeWithClient creates a new InnerTube instance which sends its
+// requests through the given client
+func NewInnerTubeWithClient(client *http.Client) (*InnerTube, error) {
+	return NewInnerTubeForClient("WEB", client)
+}
+
+// NewInnerTubeMusic creates a new InnerTube instance which searches YouTube
+// Music
+func NewInnerTubeMusic() (*InnerTube, error) {
+	return NewInnerTubeForClient("WEB_REMIX", &http.Client{})
+}
+
+// NewInnerTubeForClient creates a new InnerTube instance which identifies as
+// the named InnerTube client, such as WEB or WEB_REMIX (YouTube Music)
+func NewInnerTubeForClient(clientName string, client *http.Client) (*InnerTube, error) {
+	context := GetContext(clientName)
 
 	return &InnerTube{
-		Adaptor: NewInnerTubeAdaptor(context, &http.Client{}),
+		Adaptor: NewInnerTubeAdaptor(context, client),
 	}, nil
 }
 
@@ -66,3 +84,12 @@ func (it *InnerTube) Search(query *string, params *string, continuation *string)
 	//log.Println("Filter(body): ", Filter(body))
 	return it.Call("SEARCH", nil, Filter(body))
 }
+
+func (it *InnerTube) Browse(browseId *string, params *string, continuation *string) (map[string]interface{}, error) {
+	body := map[string]interface{}{
+		"browseId":     browseId,
+		"params":       params,
+		"continuation": continuation,
+	}
+	return it.Call("BROWSE", nil, Filter(body))
+}
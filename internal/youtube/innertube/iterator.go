@@ -0,0 +1,92 @@
+/*
+ *    Copyright (c) 2024 wslyyy
+ *
+ *    Permission is hereby granted, free of charge, to any person obtaining a copy
+ *    of this software and associated documentation files (the "Software"), to deal
+ *    in the Software without restriction, including without limitation the rights
+ *    to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *    copies of the Software, and to permit persons to whom the Software is
+ *    furnished to do so, subject to the following conditions:
+ *
+ *    The above copyright notice and this permission notice shall be included in all
+ *    copies or substantial portions of the Software.
+ *
+ *    THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *    IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *    FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *    AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *    LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *    OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *    SOFTWARE.
+ */
+
+package innertube
+
+// SearchIterator returns the videos of a Search one at a time, fetching the
+// next page by its continuation token whenever the current page runs out.
+type SearchIterator struct {
+	client       *InnerTube
+	query        string
+	params       string
+	maxPages     int
+	pages        int
+	continuation string
+	videos       []Video
+	err          error
+}
+
+// SearchVideos returns a SearchIterator over the videos found for a query.
+// At most maxPages pages are fetched. No request is made until Next is called.
+func (it *InnerTube) SearchVideos(query string, params string, maxPages int) *SearchIterator {
+	return &SearchIterator{client: it, query: query, params: params, maxPages: maxPages}
+}
+
+// Next returns the next video. It returns false once every page has been
+// read, maxPages have been fetched, or a page fails to load, in which case
+// Err returns the failure.
+func (s *SearchIterator) Next() (Video, bool) {
+	for len(s.videos) == 0 {
+		if !s.fetch() {
+			return Video{}, false
+		}
+	}
+
+	video := s.videos[0]
+	s.videos = s.videos[1:]
+	return video, true
+}
+
+// Err returns the error which stopped the SearchIterator, if any
+func (s *SearchIterator) Err() error {
+	return s.err
+}
+
+// Pages returns how many pages have been fetched
+func (s *SearchIterator) Pages() int {
+	return s.pages
+}
+
+// fetch loads the next page, returning false when there is none to load
+func (s *SearchIterator) fetch() bool {
+	if s.err != nil || s.pages >= s.maxPages || (s.pages > 0 && s.continuation == "") {
+		return false
+	}
+
+	var data map[string]interface{}
+	if s.pages == 0 {
+		data, s.err = s.client.Search(&s.query, &s.params, nil)
+	} else {
+		logger.Debug("Fetching next page of search results", "query", s.query, "page", s.pages+1)
+		data, s.err = s.client.Search(nil, nil, &s.continuation)
+	}
+	if s.err != nil {
+		return false
+	}
+
+	page := ParseSearchPage(data)
+	s.pages++
+	s.videos = page.Videos
+	s.continuation = page.Continuation
+
+	return true
+}
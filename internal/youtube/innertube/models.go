@@ -27,6 +27,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type Locale struct {
@@ -43,19 +44,44 @@ func (l *Locale) AcceptLanguage() string {
 }
 
 type Error struct {
-	code    int
-	message string
-	reason  string
+	code       int
+	message    string
+	reason     string
+	status     string
+	retryAfter time.Duration
 }
 
 func (e *Error) String() string {
 	return fmt.Sprintf("%d %s: %s", e.code, http.StatusText(e.code), e.message)
 }
 
+func (e *Error) Error() string {
+	return e.String()
+}
+
 func (e *Error) Code() int {
 	return e.code
 }
 
+func (e *Error) StatusCode() int {
+	return e.code
+}
+
+func (e *Error) Reasons() []string {
+	var reasons []string
+	if e.reason != "" {
+		reasons = append(reasons, e.reason)
+	}
+	if e.status != "" {
+		reasons = append(reasons, e.status)
+	}
+	return reasons
+}
+
+func (e *Error) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
 type ClientContext struct {
 	ClientName     string
 	ClientVersion  string
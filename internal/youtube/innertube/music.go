@@ -0,0 +1,243 @@
+/*
+ *    Copyright (c) 2024 wslyyy
+ *
+ *    Permission is hereby granted, free of charge, to any person obtaining a copy
+ *    of this software and associated documentation files (the "Software"), to deal
+ *    in the Software without restriction, including without limitation the rights
+ *    to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *    copies of the Software, and to permit persons to whom the Software is
+ *    furnished to do so, subject to the following conditions:
+ *
+ *    The above copyright notice and this permission notice shall be included in all
+ *    copies or substantial portions of the Software.
+ *
+ *    THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *    IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *    FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *    AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *    LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *    OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *    SOFTWARE.
+ */
+
+package innertube
+
+import "strings"
+
+const (
+	// MusicSongsParams filters a YouTube Music Search to songs
+	MusicSongsParams = "EgWKAQIIAWoMEA4QChADEAQQCRAF"
+	// MusicAlbumsParams filters a YouTube Music Search to albums
+	MusicAlbumsParams = "EgWKAQIYAWoMEA4QChADEAQQCRAF"
+)
+
+// Song is a single song result from a YouTube Music Search. Channel holds
+// the artists, and ChannelId the channel of the first artist.
+type Song struct {
+	Video
+	Album string
+	// VideoType is MUSIC_VIDEO_TYPE_ATV for the audio uploaded to an
+	// artist's Topic channel, or MUSIC_VIDEO_TYPE_OMV for an official
+	// music video.
+	VideoType string
+}
+
+// MusicAlbum is a single album result from a YouTube Music Search. BrowseId
+// opens the album page with Browse.
+type MusicAlbum struct {
+	BrowseId string
+	Title    string
+	Artist   string
+	Year     string
+}
+
+// AlbumPage is a YouTube Music album, with its official playlist (an
+// OLAK5uy_ ID) and its songs in order
+type AlbumPage struct {
+	PlaylistId string
+	Title      string
+	Artist     string
+	Tracks     []Song
+}
+
+// musicSeparator divides the artists, album and length of a Song
+const musicSeparator = " • "
+
+// ParseMusicSearch extracts the song results from a YouTube Music Search
+// response, in the order they were returned.
+func ParseMusicSearch(data map[string]interface{}) []Song {
+	var songs []Song
+
+	for _, item := range musicShelfItems(dig(data, "contents", "tabbedSearchResultsRenderer", "tabs")) {
+		if song, ok := parseMusicItem(item); ok {
+			songs = append(songs, song)
+		}
+	}
+
+	return songs
+}
+
+// ParseMusicAlbumSearch extracts the album results from a YouTube Music
+// Search response, in the order they were returned.
+func ParseMusicAlbumSearch(data map[string]interface{}) []MusicAlbum {
+	var albums []MusicAlbum
+
+	for _, item := range musicShelfItems(dig(data, "contents", "tabbedSearchResultsRenderer", "tabs")) {
+		browseId, _ := dig(item, "navigationEndpoint", "browseEndpoint", "browseId").(string)
+		if !strings.HasPrefix(browseId, "MPRE") {
+			continue
+		}
+
+		columns := asSlice(item["flexColumns"])
+		album := MusicAlbum{
+			BrowseId: browseId,
+			Title:    runsText(dig(columns, 0, "musicResponsiveListItemFlexColumnRenderer", "text")),
+		}
+
+		// The second column reads "Album • Artist • 2001"
+		for idx, segment := range musicSegments(dig(columns, 1, "musicResponsiveListItemFlexColumnRenderer", "text", "runs")) {
+			text := strings.TrimSpace(runsText(map[string]interface{}{"runs": segment}))
+			switch {
+			case idx == 0 && (text == "Album" || text == "EP" || text == "Single"):
+			case album.Artist == "":
+				album.Artist = text
+			default:
+				album.Year = text
+			}
+		}
+
+		albums = append(albums, album)
+	}
+
+	return albums
+}
+
+// ParseMusicAlbum extracts the official playlist and the songs of a YouTube
+// Music album page, as returned by Browse.
+func ParseMusicAlbum(data map[string]interface{}) AlbumPage {
+	page := AlbumPage{}
+
+	header := dig(data, "header", "musicDetailHeaderRenderer")
+	if header == nil {
+		header = dig(data, "contents", "twoColumnBrowseResultsRenderer", "tabs", 0, "tabRenderer", "content", "sectionListRenderer", "contents", 0, "musicResponsiveHeaderRenderer")
+	}
+	page.Title = runsText(dig(header, "title"))
+	if page.Artist = runsText(dig(header, "straplineTextOne")); page.Artist == "" {
+		if segments := musicSegments(dig(header, "subtitle", "runs")); len(segments) > 1 {
+			page.Artist = strings.TrimSpace(runsText(map[string]interface{}{"runs": segments[1]}))
+		}
+	}
+
+	shelves := []interface{}{
+		dig(data, "contents", "twoColumnBrowseResultsRenderer", "secondaryContents", "sectionListRenderer", "contents"),
+		dig(data, "contents", "singleColumnBrowseResultsRenderer", "tabs", 0, "tabRenderer", "content", "sectionListRenderer", "contents"),
+	}
+	for _, contents := range shelves {
+		for _, section := range asSlice(contents) {
+			for _, item := range asSlice(dig(section, "musicShelfRenderer", "contents")) {
+				renderer, ok := dig(item, "musicResponsiveListItemRenderer").(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				song, ok := parseMusicItem(renderer)
+				if !ok {
+					continue
+				}
+				if song.Channel == "" {
+					song.Channel = page.Artist
+				}
+				if song.Album == "" {
+					song.Album = page.Title
+				}
+				if song.Length == "" {
+					song.Length = runsText(dig(renderer, "fixedColumns", 0, "musicResponsiveListItemFixedColumnRenderer", "text"))
+				}
+				page.Tracks = append(page.Tracks, song)
+
+				if playlistId, _ := dig(renderer, "flexColumns", 0, "musicResponsiveListItemFlexColumnRenderer", "text", "runs", 0, "navigationEndpoint", "watchEndpoint", "playlistId").(string); page.PlaylistId == "" {
+					page.PlaylistId = playlistId
+				}
+			}
+		}
+	}
+
+	if page.PlaylistId == "" {
+		canonical, _ := dig(data, "microformat", "microformatDataRenderer", "urlCanonical").(string)
+		if _, playlistId, ok := strings.Cut(canonical, "list="); ok {
+			page.PlaylistId = playlistId
+		}
+	}
+
+	return page
+}
+
+// musicShelfItems returns the list item renderers of every music shelf in the
+// tabs of a YouTube Music Search response
+func musicShelfItems(tabs interface{}) []map[string]interface{} {
+	var items []map[string]interface{}
+	for _, tab := range asSlice(tabs) {
+		for _, section := range asSlice(dig(tab, "tabRenderer", "content", "sectionListRenderer", "contents")) {
+			for _, item := range asSlice(dig(section, "musicShelfRenderer", "contents")) {
+				if renderer, ok := dig(item, "musicResponsiveListItemRenderer").(map[string]interface{}); ok {
+					items = append(items, renderer)
+				}
+			}
+		}
+	}
+	return items
+}
+
+// musicSegments splits runs on the " • " separator
+func musicSegments(runs interface{}) [][]interface{} {
+	var segment []interface{}
+	var segments [][]interface{}
+	for _, run := range asSlice(runs) {
+		if text, _ := dig(run, "text").(string); text == musicSeparator {
+			segments = append(segments, segment)
+			segment = nil
+			continue
+		}
+		segment = append(segment, run)
+	}
+	return append(segments, segment)
+}
+
+func parseMusicItem(renderer map[string]interface{}) (Song, bool) {
+	columns := asSlice(renderer["flexColumns"])
+	titleRun := dig(columns, 0, "musicResponsiveListItemFlexColumnRenderer", "text", "runs", 0)
+	watch := dig(titleRun, "navigationEndpoint", "watchEndpoint")
+
+	videoId, _ := dig(renderer, "playlistItemData", "videoId").(string)
+	if videoId == "" {
+		videoId, _ = dig(watch, "videoId").(string)
+	}
+	if videoId == "" {
+		return Song{}, false
+	}
+
+	song := Song{Video: Video{VideoId: videoId}}
+	song.Title, _ = dig(titleRun, "text").(string)
+	song.VideoType, _ = dig(watch, "watchEndpointMusicSupportedConfigs", "watchEndpointMusicConfig", "musicVideoType").(string)
+
+	// The second column reads "Artist & Artist • Album • 3:45"
+	for _, segment := range musicSegments(dig(columns, 1, "musicResponsiveListItemFlexColumnRenderer", "text", "runs")) {
+		text := strings.TrimSpace(runsText(map[string]interface{}{"runs": segment}))
+		browseId, _ := dig(segment, 0, "navigationEndpoint", "browseEndpoint", "browseId").(string)
+
+		_, isLength := ParseLength(text)
+
+		switch {
+		case text == "" || text == "Song" || text == "Video":
+		case strings.HasPrefix(browseId, "MPRE"):
+			song.Album = text
+		case isLength:
+			song.Length = text
+		case song.Channel == "":
+			song.Channel = text
+			song.ChannelId = browseId
+		}
+	}
+
+	return song, true
+}
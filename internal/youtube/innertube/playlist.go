@@ -0,0 +1,50 @@
+/*
+ *    Copyright (c) 2024 wslyyy
+ *
+ *    Permission is hereby granted, free of charge, to any person obtaining a copy
+ *    of this software and associated documentation files (the "Software"), to deal
+ *    in the Software without restriction, including without limitation the rights
+ *    to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *    copies of the Software, and to permit persons to whom the Software is
+ *    furnished to do so, subject to the following conditions:
+ *
+ *    The above copyright notice and this permission notice shall be included in all
+ *    copies or substantial portions of the Software.
+ *
+ *    THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *    IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *    FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *    AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *    LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *    OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *    SOFTWARE.
+ */
+
+package innertube
+
+import "fmt"
+
+// EditPlaylist adds videos to a Playlist in a single request. The InnerTube
+// must send its requests with the Playlist owner's OAuth token. The request
+// either adds every video or none of them.
+func (it *InnerTube) EditPlaylist(playlistId string, videoIds ...string) error {
+	actions := make([]interface{}, 0, len(videoIds))
+	for _, videoId := range videoIds {
+		actions = append(actions, map[string]interface{}{"action": "ACTION_ADD_VIDEO", "addedVideoId": videoId})
+	}
+
+	body := map[string]interface{}{
+		"playlistId": playlistId,
+		"actions":    actions,
+	}
+
+	data, err := it.Call("BROWSE/EDIT_PLAYLIST", nil, body)
+	if err != nil {
+		return err
+	}
+
+	if status, _ := data["status"].(string); status != "STATUS_SUCCEEDED" {
+		return fmt.Errorf("editing playlist [%s] returned status [%s]", playlistId, status)
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+/*
+ *    Copyright (c) 2024 wslyyy
+ *
+ *    Permission is hereby granted, free of charge, to any person obtaining a copy
+ *    of this software and associated documentation files (the "Software"), to deal
+ *    in the Software without restriction, including without limitation the rights
+ *    to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *    copies of the Software, and to permit persons to whom the Software is
+ *    furnished to do so, subject to the following conditions:
+ *
+ *    The above copyright notice and this permission notice shall be included in all
+ *    copies or substantial portions of the Software.
+ *
+ *    THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *    IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *    FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *    AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *    LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *    OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *    SOFTWARE.
+ */
+
+package innertube
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// RecordMode selects whether a Recorder captures live responses or replays
+// them from disk.
+type RecordMode int
+
+const (
+	// Replay serves every request from a fixture file, failing if none exists
+	Replay RecordMode = iota
+	// Record sends every request to the live API and saves the response
+	Record
+)
+
+// sensitiveKeys are removed from recorded bodies, as they identify the session
+// or are only used for tracking.
+var sensitiveKeys = map[string]bool{
+	"responseContext":     true,
+	"trackingParams":      true,
+	"clickTrackingParams": true,
+	"visitorData":         true,
+	"adClientParams":      true,
+	"loggingDirectives":   true,
+}
+
+var slugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Fixture is a single recorded InnerTube exchange
+type Fixture struct {
+	Request  FixtureRequest         `json:"request"`
+	Status   int                    `json:"status"`
+	Response map[string]interface{} `json:"response"`
+}
+
+// FixtureRequest is the part of a request which identifies a Fixture
+type FixtureRequest struct {
+	Endpoint     string `json:"endpoint"`
+	Query        string `json:"query,omitempty"`
+	BrowseId     string `json:"browseId,omitempty"`
+	Params       string `json:"params,omitempty"`
+	Continuation string `json:"continuation,omitempty"`
+}
+
+// Name returns the file name the Fixture is stored under: a readable slug of
+// the subject, cut short if it is long, followed by a hash. Queries are hashed
+// by their full slug, so that case and spacing do not matter, and
+// continuation tokens by their exact value, so that long tokens sharing a
+// prefix stay apart.
+func (r FixtureRequest) Name() string {
+	subject := r.Query
+	if r.BrowseId != "" {
+		subject = r.BrowseId
+	}
+	if r.Continuation != "" {
+		subject = "continuation " + r.Continuation
+	}
+
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.ToLower(subject), "-"), "-")
+	key := slug
+	if r.Continuation != "" {
+		key = r.Continuation
+	}
+	hash := sha256.Sum256([]byte(key))
+
+	if len(slug) > 60 {
+		slug = strings.TrimRight(slug[:60], "-")
+	}
+	return fmt.Sprintf("%s_%s-%x.json", r.Endpoint, slug, hash[:4])
+}
+
+// Recorder is an http.RoundTripper which records InnerTube responses into
+// sanitised Fixture files, or replays them without network access.
+type Recorder struct {
+	Mode RecordMode
+	Dir  string
+	// Transport sends requests in Record mode. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+// NewRecorder returns a Recorder for the fixtures in dir
+func NewRecorder(mode RecordMode, dir string) *Recorder {
+	return &Recorder{Mode: mode, Dir: dir}
+}
+
+// Client returns an http.Client which sends requests through the Recorder
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	fixtureRequest, err := identify(req)
+	if err != nil {
+		return nil, err
+	}
+	file := filepath.Join(r.Dir, fixtureRequest.Name())
+
+	if r.Mode == Replay {
+		return replay(req, file)
+	}
+
+	return r.record(req, fixtureRequest, file)
+}
+
+func (r *Recorder) record(req *http.Request, fixtureRequest FixtureRequest, file string) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, fmt.Errorf("unable to record non-JSON response: %w", err)
+	}
+
+	fixture := Fixture{Request: fixtureRequest, Status: resp.StatusCode, Response: sanitise(data).(map[string]interface{})}
+	encoded, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(r.Dir, 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(file, append(encoded, '\n'), 0644); err != nil {
+		return nil, err
+	}
+	logger.Info("Recorded InnerTube fixture", "file", file)
+
+	return respond(req, fixture.Status, body), nil
+}
+
+// LoadFixture reads a recorded Fixture file
+func LoadFixture(file string) (*Fixture, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := &Fixture{}
+	if err := json.Unmarshal(data, fixture); err != nil {
+		return nil, fmt.Errorf("invalid fixture [%s]: %w", file, err)
+	}
+	return fixture, nil
+}
+
+func replay(req *http.Request, file string) (*http.Response, error) {
+	fixture, err := LoadFixture(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Answer as the API would for an unknown resource, so that the miss
+		// fails fast rather than being retried as a network error.
+		message := fmt.Sprintf("no fixture to replay for request [%s]", filepath.Base(file))
+		body, _ := json.Marshal(map[string]interface{}{
+			"error": map[string]interface{}{"code": http.StatusNotFound, "message": message, "status": "NOT_FOUND"},
+		})
+		return respond(req, http.StatusNotFound, body), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(fixture.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	return respond(req, fixture.Status, body), nil
+}
+
+// identify extracts the FixtureRequest from an InnerTube request, leaving the
+// request body readable.
+func identify(req *http.Request) (FixtureRequest, error) {
+	fixtureRequest := FixtureRequest{Endpoint: path.Base(req.URL.Path)}
+	if req.Body == nil {
+		return fixtureRequest, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return fixtureRequest, err
+	}
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := json.Unmarshal(body, &fixtureRequest); err != nil {
+		return fixtureRequest, fmt.Errorf("unable to identify InnerTube request: %w", err)
+	}
+	fixtureRequest.Endpoint = path.Base(req.URL.Path)
+
+	return fixtureRequest, nil
+}
+
+func respond(req *http.Request, status int, body []byte) *http.Response {
+	var compressed bytes.Buffer
+	gzw := gzip.NewWriter(&compressed)
+	_, _ = gzw.Write(body)
+	_ = gzw.Close()
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json; charset=UTF-8")
+	header.Set("Content-Encoding", "gzip")
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(&compressed),
+		ContentLength: int64(compressed.Len()),
+		Request:       req,
+	}
+}
+
+// sanitise removes session and tracking data from a decoded JSON value
+func sanitise(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if sensitiveKeys[key] {
+				delete(v, key)
+				continue
+			}
+			v[key] = sanitise(child)
+		}
+	case []interface{}:
+		for idx, child := range v {
+			v[idx] = sanitise(child)
+		}
+	}
+	return value
+}
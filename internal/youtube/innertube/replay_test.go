@@ -0,0 +1,127 @@
+/*
+ *    Copyright (c) 2024 wslyyy
+ *
+ *    Permission is hereby granted, free of charge, to any person obtaining a copy
+ *    of this software and associated documentation files (the "Software"), to deal
+ *    in the Software without restriction, including without limitation the rights
+ *    to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *    copies of the Software, and to permit persons to whom the Software is
+ *    furnished to do so, subject to the following conditions:
+ *
+ *    The above copyright notice and this permission notice shall be included in all
+ *    copies or substantial portions of the Software.
+ *
+ *    THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *    IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *    FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *    AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *    LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *    OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *    SOFTWARE.
+ */
+
+package innertube
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc adapts a function to an http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecorderSanitisesRecordings(t *testing.T) {
+	live := `{"responseContext":{"visitorData":"SECRET_VISITOR"},"trackingParams":"TRACKING",` +
+		`"contents":{"twoColumnSearchResultsRenderer":{"primaryContents":{"sectionListRenderer":{"contents":[` +
+		`{"itemSectionRenderer":{"contents":[{"videoRenderer":{"videoId":"abcdefghijk","trackingParams":"TRACKING",` +
+		`"title":{"runs":[{"text":"A Song"}]}}}]}}]}}}}}`
+
+	recorder := NewRecorder(Record, t.TempDir())
+	recorder.Transport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/search") {
+			t.Errorf("unexpected live request [%s]", req.URL.Path)
+		}
+
+		var compressed bytes.Buffer
+		gzw := gzip.NewWriter(&compressed)
+		_, _ = gzw.Write([]byte(live))
+		_ = gzw.Close()
+
+		header := http.Header{}
+		header.Set("Content-Type", "application/json; charset=UTF-8")
+		header.Set("Content-Encoding", "gzip")
+		return &http.Response{StatusCode: 200, Header: header, Body: readCloser(&compressed), Request: req}, nil
+	})
+
+	adaptor := NewInnerTubeAdaptor(ClientContext{ClientName: "WEB", ClientVersion: "1", APIKey: "SECRET_KEY"}, recorder.Client())
+	it := &InnerTube{Adaptor: adaptor}
+
+	query := "A Song"
+	data, err := it.Search(&query, nil, nil)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if videos := ParseSearch(data); len(videos) != 1 {
+		t.Fatalf("expected the live response to be returned, got %v", videos)
+	}
+
+	recorded, err := os.ReadFile(filepath.Join(recorder.Dir, FixtureRequest{Endpoint: "search", Query: query}.Name()))
+	if err != nil {
+		t.Fatalf("expected a fixture to be written: %v", err)
+	}
+	for _, secret := range []string{"SECRET_VISITOR", "SECRET_KEY", "TRACKING"} {
+		if bytes.Contains(recorded, []byte(secret)) {
+			t.Errorf("fixture contains [%s]:\n%s", secret, recorded)
+		}
+	}
+
+	replayed, err := (&InnerTube{Adaptor: NewInnerTubeAdaptor(GetContext("WEB"), NewRecorder(Replay, recorder.Dir).Client())}).Search(&query, nil, nil)
+	if err != nil {
+		t.Fatalf("replaying the recording: %v", err)
+	}
+	if videos := ParseSearch(replayed); len(videos) != 1 || videos[0].VideoId != "abcdefghijk" {
+		t.Fatalf("unexpected replayed videos: %v", videos)
+	}
+}
+
+func TestFixtureRequestName(t *testing.T) {
+	if got := (FixtureRequest{Endpoint: "search", Query: "Daft Punk - One More Time"}).Name(); got != "search_daft-punk-one-more-time-fdb21ba3.json" {
+		t.Errorf("unexpected name [%s]", got)
+	}
+
+	// Continuation tokens are long, and often only differ after the slug is cut
+	prefix := strings.Repeat("EqIDEgtkYWZ0IHB1bmsgb25lGAMgAigBMAE", 3)
+	first := FixtureRequest{Endpoint: "search", Continuation: prefix + "page2"}.Name()
+	second := FixtureRequest{Endpoint: "search", Continuation: prefix + "page3"}.Name()
+	if first == second {
+		t.Fatalf("expected different continuations to have different names, both got [%s]", first)
+	}
+	// Continuation tokens are case sensitive
+	if upper := (FixtureRequest{Endpoint: "search", Continuation: prefix + "PAGE2"}).Name(); upper == first {
+		t.Fatalf("expected continuations differing in case to have different names, both got [%s]", first)
+	}
+	// Queries are not
+	if got := (FixtureRequest{Endpoint: "search", Query: "daft punk  one more time "}).Name(); got != "search_daft-punk-one-more-time-fdb21ba3.json" {
+		t.Errorf("expected a query differing in case and spacing to share the name, got [%s]", got)
+	}
+	if len(first) > len("search_")+60+len("-00000000.json") {
+		t.Errorf("expected the slug to be cut short, got [%s]", first)
+	}
+}
+
+type nopCloser struct{ *bytes.Buffer }
+
+func (nopCloser) Close() error { return nil }
+
+func readCloser(b *bytes.Buffer) nopCloser {
+	return nopCloser{b}
+}
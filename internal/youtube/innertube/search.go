@@ -0,0 +1,200 @@
+/*
+ *    Copyright (c) 2024 wslyyy
+ *
+ *    Permission is hereby granted, free of charge, to any person obtaining a copy
+ *    of this software and associated documentation files (the "Software"), to deal
+ *    in the Software without restriction, including without limitation the rights
+ *    to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *    copies of the Software, and to permit persons to whom the Software is
+ *    furnished to do so, subject to the following conditions:
+ *
+ *    The above copyright notice and this permission notice shall be included in all
+ *    copies or substantial portions of the Software.
+ *
+ *    THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *    IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *    FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *    AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *    LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *    OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *    SOFTWARE.
+ */
+
+package innertube
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Video is a single video result from a Search
+type Video struct {
+	VideoId   string
+	Title     string
+	Channel   string
+	ChannelId string
+	Length    string
+	Views     int64
+	// Verified is set when the channel has a verified or official artist badge
+	Verified bool
+}
+
+// Duration parses the Length of the Video. Live streams and premieres have no
+// Length, and return false.
+func (v Video) Duration() (time.Duration, bool) {
+	return ParseLength(v.Length)
+}
+
+// ParseLength parses a video length as displayed by YouTube, such as "4:13"
+// or "1:02:03".
+func ParseLength(length string) (time.Duration, bool) {
+	parts := strings.Split(strings.TrimSpace(length), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, false
+	}
+
+	var seconds int
+	for _, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return 0, false
+		}
+		seconds = seconds*60 + value
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+// SearchPage is one page of Search results, with the token which fetches the
+// next page. Continuation is empty on the last page.
+type SearchPage struct {
+	Videos       []Video
+	Continuation string
+}
+
+// ParseSearch extracts the video results from a Search response, in the order
+// they were returned. Items that are not videos (ads, shelves, channels,
+// Shorts) are skipped.
+func ParseSearch(data map[string]interface{}) []Video {
+	return ParseSearchPage(data).Videos
+}
+
+// ParseSearchPage extracts the video results and the continuation token from
+// either the first page of a Search, or a page fetched by continuation.
+func ParseSearchPage(data map[string]interface{}) SearchPage {
+	page := SearchPage{}
+
+	sections := asSlice(dig(data, "contents", "twoColumnSearchResultsRenderer", "primaryContents", "sectionListRenderer", "contents"))
+	for _, command := range asSlice(data["onResponseReceivedCommands"]) {
+		sections = append(sections, asSlice(dig(command, "appendContinuationItemsAction", "continuationItems"))...)
+	}
+
+	for _, section := range sections {
+		if token, ok := dig(section, "continuationItemRenderer", "continuationEndpoint", "continuationCommand", "token").(string); ok {
+			page.Continuation = token
+			continue
+		}
+
+		items := dig(section, "itemSectionRenderer", "contents")
+		for _, item := range asSlice(items) {
+			renderer, ok := dig(item, "videoRenderer").(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if video, ok := parseVideoRenderer(renderer); ok {
+				page.Videos = append(page.Videos, video)
+			}
+		}
+	}
+
+	return page
+}
+
+func parseVideoRenderer(renderer map[string]interface{}) (Video, bool) {
+	videoId, _ := renderer["videoId"].(string)
+	if videoId == "" {
+		return Video{}, false
+	}
+
+	// Shorts are returned as videos, but open in the Shorts player
+	if dig(renderer, "navigationEndpoint", "reelWatchEndpoint") != nil {
+		return Video{}, false
+	}
+
+	video := Video{
+		VideoId: videoId,
+		Title:   runsText(renderer["title"]),
+		Channel: runsText(renderer["ownerText"]),
+	}
+
+	video.ChannelId, _ = dig(renderer, "ownerText", "runs", 0, "navigationEndpoint", "browseEndpoint", "browseId").(string)
+	video.Length, _ = dig(renderer, "lengthText", "simpleText").(string)
+	video.Views = parseViews(runsText(renderer["viewCountText"]))
+
+	for _, badge := range asSlice(renderer["ownerBadges"]) {
+		switch dig(badge, "metadataBadgeRenderer", "style") {
+		case "BADGE_STYLE_TYPE_VERIFIED", "BADGE_STYLE_TYPE_VERIFIED_ARTIST":
+			video.Verified = true
+		}
+	}
+
+	return video, true
+}
+
+// parseViews reads a view count as displayed by YouTube, such as
+// "1,204 views". Unreadable counts are zero.
+func parseViews(text string) int64 {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, text)
+
+	views, _ := strconv.ParseInt(digits, 10, 64)
+	return views
+}
+
+// runsText joins the text of every run in a text object, falling back to its
+// simpleText.
+func runsText(value interface{}) string {
+	if simpleText, ok := dig(value, "simpleText").(string); ok {
+		return simpleText
+	}
+
+	text := ""
+	for _, run := range asSlice(dig(value, "runs")) {
+		if runText, ok := dig(run, "text").(string); ok {
+			text += runText
+		}
+	}
+	return text
+}
+
+// dig walks nested JSON maps and slices, returning nil if any step is missing
+func dig(value interface{}, keys ...interface{}) interface{} {
+	for _, key := range keys {
+		switch k := key.(type) {
+		case string:
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return nil
+			}
+			value = m[k]
+		case int:
+			s, ok := value.([]interface{})
+			if !ok || k >= len(s) {
+				return nil
+			}
+			value = s[k]
+		}
+	}
+	return value
+}
+
+func asSlice(value interface{}) []interface{} {
+	s, _ := value.([]interface{})
+	return s
+}
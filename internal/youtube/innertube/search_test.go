@@ -0,0 +1,241 @@
+/*
+ *    Copyright (c) 2024 wslyyy
+ *
+ *    Permission is hereby granted, free of charge, to any person obtaining a copy
+ *    of this software and associated documentation files (the "Software"), to deal
+ *    in the Software without restriction, including without limitation the rights
+ *    to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ *    copies of the Software, and to permit persons to whom the Software is
+ *    furnished to do so, subject to the following conditions:
+ *
+ *    The above copyright notice and this permission notice shall be included in all
+ *    copies or substantial portions of the Software.
+ *
+ *    THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ *    IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ *    FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ *    AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ *    LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ *    OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ *    SOFTWARE.
+ */
+
+package innertube
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+const (
+	searchFixtures = "testdata/search"
+	musicFixtures  = "testdata/music"
+)
+
+// TestParseSearchGolden parses every recorded search and compares the videos
+// found with the golden file of the same name.
+func TestParseSearchGolden(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(searchFixtures, "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no search fixtures found: %v", err)
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(name, func(t *testing.T) {
+			fixture, err := LoadFixture(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			videos := ParseSearch(fixture.Response)
+			if videos == nil {
+				videos = []Video{}
+			}
+
+			golden := filepath.Join(searchFixtures, "golden", name+".golden.json")
+			assertGolden(t, golden, videos)
+		})
+	}
+}
+
+// TestParseMusicSearchGolden parses every recorded YouTube Music search and
+// album page, and compares the results with the golden file of the same name.
+func TestParseMusicSearchGolden(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(musicFixtures, "*.json"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("no music fixtures found: %v", err)
+	}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		t.Run(name, func(t *testing.T) {
+			fixture, err := LoadFixture(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var parsed interface{}
+			switch {
+			case fixture.Request.Endpoint == "browse":
+				parsed = ParseMusicAlbum(fixture.Response)
+			case fixture.Request.Params == MusicAlbumsParams:
+				parsed = ParseMusicAlbumSearch(fixture.Response)
+			default:
+				songs := ParseMusicSearch(fixture.Response)
+				if songs == nil {
+					songs = []Song{}
+				}
+				parsed = songs
+			}
+
+			golden := filepath.Join(musicFixtures, "golden", name+".golden.json")
+			assertGolden(t, golden, parsed)
+		})
+	}
+}
+
+func TestParseSearchSkipsNonVideos(t *testing.T) {
+	fixture, err := LoadFixture(filepath.Join(searchFixtures, "search_daft-punk-one-more-time-fdb21ba3.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, video := range ParseSearch(fixture.Response) {
+		switch video.VideoId {
+		case "adadadadad1", "shortvideo1", "shortshort1", "harderbettr":
+			t.Errorf("expected [%s] to be skipped", video.VideoId)
+		}
+	}
+}
+
+func TestRecorderReplaysFixtures(t *testing.T) {
+	recorder := NewRecorder(Replay, searchFixtures)
+	it, err := NewInnerTubeWithClient(recorder.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := "Massive Attack"
+	params := "EgIQAQ%3D%3D"
+	data, err := it.Search(&query, &params, nil)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	if videos := ParseSearch(data); len(videos) != 3 {
+		t.Fatalf("expected 3 videos, got [%d]", len(videos))
+	}
+
+	missing := "Not Recorded"
+	if _, err := it.Search(&missing, &params, nil); err == nil {
+		t.Fatal("expected an error replaying a request with no fixture")
+	}
+}
+
+func TestSearchIteratorFollowsContinuations(t *testing.T) {
+	it, err := NewInnerTubeWithClient(NewRecorder(Replay, searchFixtures).Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for maxPages, want := range map[int][]string{
+		1: {"labelMix001", "deepCutPod1", "labelDocu01"},
+		3: {"labelMix001", "deepCutPod1", "labelDocu01", "labelMix002", "deepCutOrig", "deepCutCovr"},
+	} {
+		results := it.SearchVideos("Lost Label Deep Cut", "EgIQAQ%3D%3D", maxPages)
+
+		var got []string
+		for video, ok := results.Next(); ok; video, ok = results.Next() {
+			got = append(got, video.VideoId)
+		}
+
+		if results.Err() != nil {
+			t.Errorf("unexpected error reading [%d] pages: %v", maxPages, results.Err())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("reading [%d] pages, expected %v, got %v", maxPages, want, got)
+		}
+	}
+}
+
+func TestSearchIteratorStopsOnError(t *testing.T) {
+	it, err := NewInnerTubeWithClient(NewRecorder(Replay, searchFixtures).Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second page of this search was never recorded
+	results := it.SearchVideos("Daft Punk One More Time", "EgIQAQ%3D%3D", 2)
+
+	count := 0
+	for _, ok := results.Next(); ok; _, ok = results.Next() {
+		count++
+	}
+
+	if count == 0 || results.Pages() != 1 {
+		t.Errorf("expected the first page to be read, got [%d] videos from [%d] pages", count, results.Pages())
+	}
+	if results.Err() == nil {
+		t.Error("expected an error loading the second page")
+	}
+}
+
+func assertGolden(t *testing.T, golden string, got interface{}) {
+	t.Helper()
+
+	encoded, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded = append(encoded, '\n')
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, encoded, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("missing golden file, run with -update: %v", err)
+	}
+
+	var wantValue, gotValue interface{}
+	_ = json.Unmarshal(want, &wantValue)
+	_ = json.Unmarshal(encoded, &gotValue)
+	if !reflect.DeepEqual(wantValue, gotValue) {
+		t.Errorf("result does not match [%s]\ngot:\n%s\nwant:\n%s", golden, encoded, want)
+	}
+}
+
+func TestParseLength(t *testing.T) {
+	tests := map[string]time.Duration{
+		"0:31":    31 * time.Second,
+		"5:20":    5*time.Minute + 20*time.Second,
+		"1:00:00": time.Hour,
+		"1:02:03": time.Hour + 2*time.Minute + 3*time.Second,
+	}
+	for length, want := range tests {
+		if got, ok := ParseLength(length); !ok || got != want {
+			t.Errorf("ParseLength(%q) = %v, %v, want %v", length, got, ok, want)
+		}
+	}
+
+	for _, length := range []string{"", "LIVE", "5", "1:2:3:4", "a:b", "-1:00"} {
+		if got, ok := ParseLength(length); ok {
+			t.Errorf("ParseLength(%q) = %v, expected it to fail", length, got)
+		}
+	}
+}
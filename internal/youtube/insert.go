@@ -0,0 +1,296 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/retry"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/youtube/v3"
+)
+
+const (
+	playlistItemsInsertCost = 50
+	// maxBatchSize is the most calls the Data API accepts in one batch request
+	maxBatchSize = 50
+	// maxEditActions is how many videos are added by one InnerTube request
+	maxEditActions = 100
+)
+
+// insertFunc adds videos to a Playlist, returning an error for each video
+// which was not added
+type insertFunc func(playlistId string, videoIds []string) []error
+
+// inserter returns the insertFunc for the insert method of the run, and how
+// many videos it is given at a time
+func (yt *YouTube) inserter() (insertFunc, int) {
+	switch yt.insertMethod {
+	case config.InsertBatch:
+		return yt.insertBatch, maxBatchSize
+	case config.InsertInnerTube:
+		return yt.insertInnerTube, maxEditActions
+	default:
+		return yt.insertEach, 1
+	}
+}
+
+// insertEach adds videos with one PlaylistItems.Insert call each
+func (yt *YouTube) insertEach(playlistId string, videoIds []string) []error {
+	errs := make([]error, len(videoIds))
+
+	for idx, videoId := range videoIds {
+		call := yt.client.PlaylistItems.Insert([]string{"snippet"}, newPlaylistItem(playlistId, videoId))
+
+		errs[idx] = yt.doInsert(func() error {
+			_, err := call.Do()
+			return err
+		}, func() bool {
+			return yt.hasVideo(playlistId, videoId)
+		})
+		if errs[idx] == nil {
+			yt.Credits += playlistItemsInsertCost
+		}
+	}
+
+	return errs
+}
+
+// insertBatch adds videos with a single Data API batch request. Each insert
+// still costs its Credits, but the videos are sent in one round trip. Only the
+// parts which failed with a retryable error are sent again, and if the batch
+// may have been applied the Playlist is read first, so that no video is added
+// twice.
+func (yt *YouTube) insertBatch(playlistId string, videoIds []string) []error {
+	errs := make([]error, len(videoIds))
+	pending := make([]int, len(videoIds))
+	for idx := range pending {
+		pending[idx] = idx
+	}
+
+	_ = yt.doInsert(func() (err error) {
+		pending, err = yt.sendBatch(playlistId, videoIds, pending, errs)
+		return err
+	}, func() bool {
+		missing := yt.missingVideos(playlistId, videoIds, pending, errs)
+		yt.Credits += (len(pending) - len(missing)) * playlistItemsInsertCost
+		pending = missing
+		return len(pending) == 0
+	})
+
+	logger.Debug("Sent batch of inserts", "playlistId", playlistId, "videos", len(videoIds))
+	return errs
+}
+
+// sendBatch posts one multipart/mixed request holding a PlaylistItems.Insert
+// call for each pending video, and records the outcome of each in errs. It
+// returns the videos which failed with a retryable error, and the error to
+// retry them with, preferring one which leaves it unclear if the insert was
+// applied over a rate limit refusal.
+func (yt *YouTube) sendBatch(playlistId string, videoIds []string, pending []int, errs []error) ([]int, error) {
+	parts, err := yt.postBatch(playlistId, videoIds, pending)
+	if err != nil {
+		for _, idx := range pending {
+			errs[idx] = err
+		}
+		return pending, err
+	}
+
+	var failed []int
+	var retryErr error
+	for _, idx := range pending {
+		part := parts[idx]
+		if part == nil {
+			errs[idx] = fmt.Errorf("no response to batched insert of video [%s]", videoIds[idx])
+			continue
+		}
+
+		errs[idx] = googleapi.CheckResponse(part)
+		_ = part.Body.Close()
+		switch {
+		case errs[idx] == nil:
+			yt.Credits += playlistItemsInsertCost
+		case retry.Classify(errs[idx]) == retry.Retryable:
+			failed = append(failed, idx)
+			if retryErr == nil || retry.RateLimited(retryErr) {
+				retryErr = errs[idx]
+			}
+		}
+	}
+
+	return failed, retryErr
+}
+
+// missingVideos reads the Playlist and returns the pending videos which it
+// does not hold. The others were added, so their errors are cleared.
+func (yt *YouTube) missingVideos(playlistId string, videoIds []string, pending []int, errs []error) []int {
+	present := make(map[string]bool)
+	for _, item := range yt.GetPlaylistItems(playlistId) {
+		present[item.Snippet.ResourceId.VideoId] = true
+	}
+
+	var missing []int
+	for _, idx := range pending {
+		if !present[videoIds[idx]] {
+			missing = append(missing, idx)
+			continue
+		}
+		errs[idx] = nil
+	}
+	return missing
+}
+
+// postBatch posts one multipart/mixed request holding a PlaylistItems.Insert
+// call for each pending video, and returns the responses indexed as videoIds
+func (yt *YouTube) postBatch(playlistId string, videoIds []string, pending []int) ([]*http.Response, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for _, idx := range pending {
+		item, err := json.Marshal(newPlaylistItem(playlistId, videoIds[idx]))
+		if err != nil {
+			return nil, err
+		}
+
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {"application/http"},
+			"Content-Id":   {fmt.Sprintf("<item%d>", idx)},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(part, "POST /youtube/v3/playlistItems?part=snippet&alt=json HTTP/1.1\r\n")
+		fmt.Fprintf(part, "Content-Type: application/json\r\nContent-Length: %d\r\n\r\n", len(item))
+		_, _ = part.Write(item)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, yt.client.BasePath+"batch/youtube/v3", &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "multipart/mixed; boundary="+writer.Boundary())
+
+	resp, err := yt.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := googleapi.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return readBatchResponse(resp, len(videoIds))
+}
+
+// readBatchResponse splits a multipart/mixed batch response into the response
+// to each call, matched by the Content-ID of its part
+func readBatchResponse(resp *http.Response, count int) ([]*http.Response, error) {
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid batch response: %w", err)
+	}
+
+	responses := make([]*http.Response, count)
+	reader := multipart.NewReader(resp.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid batch response: %w", err)
+		}
+
+		contentId := strings.Trim(part.Header.Get("Content-Id"), "<>")
+		idx, err := strconv.Atoi(strings.TrimPrefix(contentId, "response-item"))
+		if err != nil || idx < 0 || idx >= count {
+			return nil, fmt.Errorf("unexpected part [%s] in batch response", contentId)
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		partResp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid part [%s] in batch response: %w", contentId, err)
+		}
+		responses[idx] = partResp
+	}
+
+	return responses, nil
+}
+
+// insertInnerTube adds every video in one InnerTube request, authorised with
+// the same OAuth token, which costs no Credits. InnerTube rejects the whole
+// request if any video cannot be added, in which case the videos are added
+// one at a time through the Data API instead.
+func (yt *YouTube) insertInnerTube(playlistId string, videoIds []string) []error {
+	err := yt.editClient.EditPlaylist(playlistId, videoIds...)
+	if err == nil {
+		return make([]error, len(videoIds))
+	}
+
+	// The request may have been applied even though it failed, so only the
+	// videos which the Playlist does not hold are added again
+	errs := make([]error, len(videoIds))
+	pending := make([]int, len(videoIds))
+	for idx := range pending {
+		pending[idx] = idx
+	}
+	pending = yt.missingVideos(playlistId, videoIds, pending, errs)
+
+	missing := make([]string, len(pending))
+	for idx, videoIdx := range pending {
+		missing[idx] = videoIds[videoIdx]
+	}
+
+	logger.Warn("InnerTube could not add the videos. Adding them through the Data API.", "playlistId", playlistId, "videos", len(missing), "error", err)
+	for idx, err := range yt.insertEach(playlistId, missing) {
+		errs[pending[idx]] = err
+	}
+	return errs
+}
+
+func newPlaylistItem(playlistId, videoId string) *youtube.PlaylistItem {
+	return &youtube.PlaylistItem{
+		Snippet: &youtube.PlaylistItemSnippet{
+			PlaylistId: playlistId,
+			ResourceId: &youtube.ResourceId{
+				Kind:    "youtube#video",
+				VideoId: videoId,
+			},
+		},
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube/innertube"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// newReplayYouTube returns a YouTube whose InnerTube searches are served from
+// the recorded fixtures. Set INNERTUBE_RECORD=1 to refresh the fixtures from
+// the live API instead.
+func newReplayYouTube(t *testing.T) *YouTube {
+	t.Helper()
+
+	mode := innertube.Replay
+	if os.Getenv("INNERTUBE_RECORD") == "1" {
+		mode = innertube.Record
+	}
+
+	it, err := innertube.NewInnerTubeWithClient(innertube.NewRecorder(mode, "innertube/testdata/search").Client())
+	if err != nil {
+		t.Fatalf("NewInnerTubeWithClient: %v", err)
+	}
+	music, err := innertube.NewInnerTubeForClient("WEB_REMIX", innertube.NewRecorder(mode, "innertube/testdata/music").Client())
+	if err != nil {
+		t.Fatalf("NewInnerTubeForClient: %v", err)
+	}
+
+	yt, _ := newTestYouTube(t)
+	yt.SetInnerTube(it)
+	yt.SetInnerTubeMusic(music)
+	return yt
+}
+
+func TestGetTrackUnofficialGolden(t *testing.T) {
+	yt := newReplayYouTube(t)
+
+	tracks := map[string]Track{
+		"daft-punk-one-more-time": {SpotifyId: "0DiWol3AO6WpXZgp0goxAV", Artist: "Daft Punk", Name: "One More Time", Duration: 320000},
+		"channel-result":          {SpotifyId: "massiveattack000000000", Artist: "Massive Attack"},
+		"fewer-than-max-results":  {SpotifyId: "obscure000000000000000", Artist: "Obscure Artist", Name: "Very Rare B-Side"},
+		"no-results":              {SpotifyId: "nobody0000000000000000", Artist: "Nobody Has", Name: "Uploaded This"},
+		"second-page":             {SpotifyId: "deepcut000000000000000", Artist: "Lost Label", Name: "Deep Cut", Duration: 241000},
+		"isrc-match":              {SpotifyId: "0DiWol3AO6WpXZgp0goxAV", ISRC: "GBDUW0000059", Artist: "Daft Punk", Name: "One More Time", Duration: 320000},
+		"isrc-different-song":     {SpotifyId: "0DiWol3AO6WpXZgp0goxAV", ISRC: "ZZZZZ9999999", Artist: "Daft Punk", Name: "One More Time", Duration: 320000},
+		"isrc-no-songs":           {SpotifyId: "massiveattack000000000", ISRC: "USUM70000000", Artist: "Massive Attack"},
+		"isrc-not-recorded":       {SpotifyId: "obscure000000000000000", ISRC: "XX0000000000", Artist: "Obscure Artist", Name: "Very Rare B-Side"},
+	}
+
+	for name, track := range tracks {
+		t.Run(name, func(t *testing.T) {
+			match, err := yt.GetTrackUnofficial(track, 5)
+			if err != nil {
+				t.Fatalf("GetTrackUnofficial: %v", err)
+			}
+			if len(match.Candidates) > 5 {
+				t.Errorf("expected at most 5 Candidates, got [%d]", len(match.Candidates))
+			}
+
+			assertGolden(t, filepath.Join("testdata", "match", name+".golden.json"), match)
+		})
+	}
+}
+
+func assertGolden(t *testing.T, golden string, got interface{}) {
+	t.Helper()
+
+	encoded, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded = append(encoded, '\n')
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, encoded, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("missing golden file, run with -update: %v", err)
+	}
+
+	var wantValue, gotValue interface{}
+	_ = json.Unmarshal(want, &wantValue)
+	_ = json.Unmarshal(encoded, &gotValue)
+	if !reflect.DeepEqual(wantValue, gotValue) {
+		t.Errorf("result does not match [%s]\ngot:\n%s\nwant:\n%s", golden, encoded, want)
+	}
+}
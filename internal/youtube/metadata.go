@@ -0,0 +1,280 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"google.golang.org/api/youtube/v3"
+)
+
+const (
+	// maxTitleLength and maxDescriptionLength are the longest title and
+	// description YouTube accepts for a Playlist
+	maxTitleLength       = 150
+	maxDescriptionLength = 5000
+	playlistsUpdateCost  = 50
+)
+
+var markerPattern = regexp.MustCompile(`\[spotify:([0-9A-Za-z]+(?:/[0-9]+)?)\]`)
+
+// PlaylistSource describes the Spotify Playlist or Album that a YouTube
+// Playlist is converted from. It is the data given to the title and
+// description templates.
+type PlaylistSource struct {
+	SpotifyId   string `json:"spotifyId"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	URL         string `json:"url,omitempty"`
+	// ImageURL is the cover of the Spotify Playlist or Album, if it has one
+	ImageURL string `json:"imageUrl,omitempty"`
+	// Date is the day the YouTube Playlist was created. It is filled in by
+	// CreatePlaylist.
+	Date string `json:"-"`
+	// Part is the position of the YouTube Playlist when the source is split
+	// across several. Zero and one are the first part.
+	Part int `json:"part,omitempty"`
+}
+
+// Key identifies the YouTube Playlist converted from the source: the Spotify
+// ID, followed by the Part after the first
+func (source PlaylistSource) Key() string {
+	if source.Part > 1 && source.SpotifyId != "" {
+		return fmt.Sprintf("%s/%d", source.SpotifyId, source.Part)
+	}
+	return source.SpotifyId
+}
+
+// playlistMetadata renders the title and description of Playlists, and holds
+// the settings given to new Playlists
+type playlistMetadata struct {
+	title       *template.Template
+	description *template.Template
+	privacy     string
+	language    string
+	tags        []string
+	update      bool
+}
+
+func newPlaylistMetadata(cfg *config.Config) (*playlistMetadata, error) {
+	title, err := template.New("playlistTitle").Option("missingkey=error").Parse(cfg.PlaylistTitle)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.ValidateTitle(title); err != nil {
+		return nil, err
+	}
+	description, err := template.New("playlistDescription").Option("missingkey=error").Parse(cfg.PlaylistDescription)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fields which PlaylistSource does not have are only found by executing
+	for _, tmpl := range []*template.Template{title, description} {
+		if err := tmpl.Execute(&strings.Builder{}, PlaylistSource{}); err != nil {
+			return nil, fmt.Errorf("%s is not a valid template: %w", tmpl.Name(), err)
+		}
+	}
+
+	return &playlistMetadata{
+		title:       title,
+		description: description,
+		privacy:     cfg.PlaylistPrivacy,
+		language:    cfg.PlaylistLanguage,
+		tags:        cfg.PlaylistTags,
+		update:      cfg.UpdateMetadata,
+	}, nil
+}
+
+// PlaylistTitle returns the title of the YouTube Playlist converted from the
+// source. Parts after the first end in "(Part N)".
+func (yt *YouTube) PlaylistTitle(source PlaylistSource) string {
+	suffix := ""
+	if source.Part > 1 {
+		suffix = fmt.Sprintf(" (Part %d)", source.Part)
+	}
+
+	title := strings.TrimSpace(render(yt.metadata.title, source, maxTitleLength-len(suffix)))
+	if title == "" {
+		title = render(nil, PlaylistSource{Name: source.Name}, maxTitleLength-len(suffix))
+	}
+	return title + suffix
+}
+
+// playlistDescription renders the description of the Playlist converted from
+// the source, ending in the marker of its Spotify ID
+func (yt *YouTube) playlistDescription(source PlaylistSource) string {
+	if source.SpotifyId == "" {
+		return strings.TrimSpace(render(yt.metadata.description, source, maxDescriptionLength))
+	}
+
+	marker := sourceMarker(source.Key())
+	description := strings.TrimSpace(render(yt.metadata.description, source, maxDescriptionLength-len(marker)-2))
+	if description == "" {
+		return marker
+	}
+	return description + "\n\n" + marker
+}
+
+// newPlaylist returns a Playlist with the metadata of the run
+func (yt *YouTube) newPlaylist(source PlaylistSource) *youtube.Playlist {
+	return &youtube.Playlist{
+		Snippet: &youtube.PlaylistSnippet{
+			Title:           yt.PlaylistTitle(source),
+			Description:     yt.playlistDescription(source),
+			Tags:            yt.metadata.tags,
+			DefaultLanguage: yt.metadata.language,
+		},
+		Status: &youtube.PlaylistStatus{
+			PrivacyStatus: yt.metadata.privacy,
+		},
+	}
+}
+
+// sourceMarker is added to the description of every Playlist, so that it is
+// found by the Key of its source even after being renamed
+func sourceMarker(key string) string {
+	return "[spotify:" + key + "]"
+}
+
+// markerOf returns the source Key in the marker of a description, or an empty
+// string if it has none
+func markerOf(description string) string {
+	matches := markerPattern.FindAllStringSubmatch(description, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	return matches[len(matches)-1][1]
+}
+
+// findConverted returns the Playlist converted from the source. That is the
+// Playlist it is linked to, then the Playlist with its marker, then a Playlist
+// of the same title without any marker, made before markers were added.
+func findConverted(playlists []*youtube.Playlist, source PlaylistSource, linkedId, title string) *youtube.Playlist {
+	if linkedId != "" {
+		for _, playlist := range playlists {
+			if playlist.Id == linkedId {
+				return playlist
+			}
+		}
+	}
+
+	if source.SpotifyId != "" {
+		for _, playlist := range playlists {
+			if markerOf(playlist.Snippet.Description) == source.Key() {
+				return playlist
+			}
+		}
+	}
+
+	for _, playlist := range playlists {
+		if playlist.Snippet.Title == title && markerOf(playlist.Snippet.Description) == "" {
+			return playlist
+		}
+	}
+
+	return nil
+}
+
+// syncMetadata brings an existing Playlist in line with its source. A renamed
+// source renames the Playlist, and a Playlist without the marker of its source
+// has the marker added. With UpdateMetadata, the description, tags and
+// language are also rewritten when they differ from the settings of the run,
+// such as after the Spotify description was changed. The Date is when the
+// Playlist was created, so that the description does not change from day to
+// day.
+func (yt *YouTube) syncMetadata(playlist *youtube.Playlist, source PlaylistSource) {
+	source.Date = createdDate(playlist)
+
+	snippet := &youtube.PlaylistSnippet{
+		Title:           yt.PlaylistTitle(source),
+		Description:     playlist.Snippet.Description,
+		Tags:            playlist.Snippet.Tags,
+		DefaultLanguage: playlist.Snippet.DefaultLanguage,
+	}
+	switch {
+	case yt.metadata.update:
+		snippet.Description = yt.playlistDescription(source)
+		snippet.Tags = yt.metadata.tags
+		snippet.DefaultLanguage = yt.metadata.language
+	case source.SpotifyId != "" && markerOf(snippet.Description) != source.Key():
+		snippet.Description = strings.TrimSpace(snippet.Description + "\n\n" + sourceMarker(source.Key()))
+	}
+
+	if snippet.Title == playlist.Snippet.Title && snippet.Description == playlist.Snippet.Description &&
+		slices.Equal(snippet.Tags, playlist.Snippet.Tags) && snippet.DefaultLanguage == playlist.Snippet.DefaultLanguage {
+		return
+	}
+
+	call := yt.client.Playlists.Update([]string{"snippet"}, &youtube.Playlist{Id: playlist.Id, Snippet: snippet})
+	err := yt.do(func() error {
+		_, err := call.Do()
+		return err
+	})
+	if err != nil {
+		logger.Warn("Error updating Playlist", "name", playlist.Snippet.Title, "playlistId", playlist.Id, "error", err)
+		return
+	}
+	yt.Credits += playlistsUpdateCost
+
+	if snippet.Title != playlist.Snippet.Title {
+		logger.Info("Renamed Playlist", "from", playlist.Snippet.Title, "to", snippet.Title, "playlistId", playlist.Id)
+	} else {
+		logger.Info("Updated Playlist", "name", snippet.Title, "playlistId", playlist.Id)
+	}
+	snippet.PublishedAt = playlist.Snippet.PublishedAt
+	snippet.ChannelId = playlist.Snippet.ChannelId
+	playlist.Snippet = snippet
+}
+
+// render executes a template, falling back to the Name of the source if it
+// fails. YouTube rejects angle brackets in Playlist metadata, so they are
+// removed, and the result is cut to at most limit bytes.
+func render(tmpl *template.Template, source PlaylistSource, limit int) string {
+	text := source.Name
+	if tmpl != nil {
+		var builder strings.Builder
+		if err := tmpl.Execute(&builder, source); err != nil {
+			logger.Warn("Error rendering Playlist template. Using the Spotify name.", "template", tmpl.Name(), "error", err)
+		} else {
+			text = builder.String()
+		}
+	}
+
+	text = strings.NewReplacer("<", "", ">", "").Replace(text)
+	for len(text) > limit {
+		_, size := utf8.DecodeLastRuneInString(text)
+		text = text[:len(text)-size]
+	}
+	return text
+}
+
+// createdDate returns the day a Playlist was created, or today if unknown
+func createdDate(playlist *youtube.Playlist) string {
+	if published, err := time.Parse(time.RFC3339, playlist.Snippet.PublishedAt); err == nil {
+		return published.Format(time.DateOnly)
+	}
+	return time.Now().Format(time.DateOnly)
+}
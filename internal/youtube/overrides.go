@@ -0,0 +1,121 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// OverridesVersion is the version of the overrides file format understood by
+// this build.
+const OverridesVersion = 1
+
+// Overrides pin Tracks to specific videos, and ban videos and channels which
+// must never be chosen as a match.
+type Overrides struct {
+	Version int `json:"version"`
+	// Pins maps a Spotify Track ID or ISRC to the Video ID it should always use
+	Pins           map[string]string `json:"pins"`
+	BannedVideos   []string          `json:"bannedVideos"`
+	BannedChannels []string          `json:"bannedChannels"`
+
+	bannedVideos   map[string]bool
+	bannedChannels map[string]bool
+}
+
+// LoadOverrides reads the overrides file at path
+func LoadOverrides(path string) (*Overrides, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read overrides file [%s]: %w", path, err)
+	}
+
+	overrides := &Overrides{}
+	if err := json.Unmarshal(data, overrides); err != nil {
+		return nil, fmt.Errorf("unable to parse overrides file [%s]: %w", path, err)
+	}
+
+	if overrides.Version != OverridesVersion {
+		return nil, fmt.Errorf("overrides file [%s] has version [%d], expected [%d]", path, overrides.Version, OverridesVersion)
+	}
+
+	overrides.index()
+	if err := overrides.validate(); err != nil {
+		return nil, fmt.Errorf("overrides file [%s]: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// validate reads each pin as a video ID or URL, keeping only the ID, and
+// reports the first pin which is not a video or is banned
+func (o *Overrides) validate() error {
+	for key, pin := range o.Pins {
+		videoId, ok := ParseVideoId(pin)
+		if !ok {
+			return fmt.Errorf("pin for [%s] is not a YouTube video: [%s]", key, pin)
+		}
+		if o.bannedVideos[videoId] {
+			return fmt.Errorf("pin for [%s] is a banned video: [%s]", key, videoId)
+		}
+		o.Pins[key] = videoId
+	}
+	return nil
+}
+
+func (o *Overrides) index() {
+	o.bannedVideos = make(map[string]bool)
+	for _, videoId := range o.BannedVideos {
+		o.bannedVideos[videoId] = true
+	}
+
+	o.bannedChannels = make(map[string]bool)
+	for _, channelId := range o.BannedChannels {
+		o.bannedChannels[channelId] = true
+	}
+}
+
+// Pinned returns the Video ID pinned to a Track, checking the Spotify Track ID
+// before the ISRC. A pin to a banned video is ignored.
+func (o *Overrides) Pinned(track Track) (string, bool) {
+	if o == nil {
+		return "", false
+	}
+
+	for _, key := range []string{track.SpotifyId, track.ISRC, strings.ToUpper(track.ISRC)} {
+		if key == "" {
+			continue
+		}
+		if videoId, ok := o.Pins[key]; ok && !o.bannedVideos[videoId] {
+			return videoId, true
+		}
+	}
+
+	return "", false
+}
+
+// Banned reports whether a video, or the channel which uploaded it, must never
+// be chosen.
+func (o *Overrides) Banned(videoId, channelId string) bool {
+	if o == nil {
+		return false
+	}
+
+	return o.bannedVideos[videoId] || (channelId != "" && o.bannedChannels[channelId])
+}
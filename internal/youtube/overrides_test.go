@@ -0,0 +1,136 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeOverrides(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "overrides.json")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadOverrides(t *testing.T) {
+	path := writeOverrides(t, `{
+		"version": 1,
+		"pins": {"spotifytrack1": "pinnedvideo", "GBAAA0000001": "https://youtu.be/isrcvideo01"},
+		"bannedVideos": ["bannedvideo"],
+		"bannedChannels": ["UCbanned"]
+	}`)
+
+	overrides, err := LoadOverrides(path)
+	if err != nil {
+		t.Fatalf("LoadOverrides: %v", err)
+	}
+	if len(overrides.Pins) != 2 || !overrides.Banned("bannedvideo", "") || !overrides.Banned("othervideo1", "UCbanned") {
+		t.Fatalf("unexpected Overrides: %+v", overrides)
+	}
+	// A pinned URL is kept as its video ID
+	if videoId, _ := overrides.Pinned(Track{ISRC: "GBAAA0000001"}); videoId != "isrcvideo01" {
+		t.Fatalf("expected the pinned URL to be read as [isrcvideo01], got [%s]", videoId)
+	}
+}
+
+func TestLoadOverridesRejectsBadFiles(t *testing.T) {
+	tests := map[string]struct {
+		path string
+		want string
+	}{
+		"missing":       {filepath.Join(t.TempDir(), "missing.json"), "unable to read"},
+		"invalid JSON":  {writeOverrides(t, `{"version": 1,`), "unable to parse"},
+		"wrong version": {writeOverrides(t, `{"version": 2}`), "has version [2]"},
+		"no version":    {writeOverrides(t, `{"pins": {}}`), "has version [0]"},
+		"invalid pin":   {writeOverrides(t, `{"version": 1, "pins": {"spotifytrack1": "not a video"}}`), "is not a YouTube video"},
+		"banned pin":    {writeOverrides(t, `{"version": 1, "pins": {"spotifytrack1": "bannedvideo"}, "bannedVideos": ["bannedvideo"]}`), "is a banned video"},
+	}
+
+	for name, test := range tests {
+		if _, err := LoadOverrides(test.path); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: expected an error containing [%s], got [%v]", name, test.want, err)
+		}
+	}
+}
+
+func TestOverridesPinned(t *testing.T) {
+	overrides := &Overrides{Pins: map[string]string{
+		"spotifytrack1": "pinnedvideo",
+		"GBAAA0000001":  "isrcvideo01",
+	}}
+
+	tests := map[string]struct {
+		track Track
+		want  string
+	}{
+		"by Spotify ID":      {Track{SpotifyId: "spotifytrack1"}, "pinnedvideo"},
+		"Spotify ID first":   {Track{SpotifyId: "spotifytrack1", ISRC: "GBAAA0000001"}, "pinnedvideo"},
+		"by ISRC":            {Track{SpotifyId: "spotifytrack2", ISRC: "GBAAA0000001"}, "isrcvideo01"},
+		"lowercase ISRC":     {Track{SpotifyId: "spotifytrack2", ISRC: "gbaaa0000001"}, "isrcvideo01"},
+		"not pinned":         {Track{SpotifyId: "spotifytrack2", ISRC: "GBAAA0000002"}, ""},
+		"no IDs":             {Track{Artist: "Band", Name: "Song"}, ""},
+		"empty Spotify ID":   {Track{ISRC: "GBAAA0000002"}, ""},
+		"local file by ISRC": {Track{ISRC: "GBAAA0000001"}, "isrcvideo01"},
+	}
+
+	for name, test := range tests {
+		videoId, ok := overrides.Pinned(test.track)
+		if videoId != test.want || ok != (test.want != "") {
+			t.Errorf("%s: expected [%s], got [%s] [%v]", name, test.want, videoId, ok)
+		}
+	}
+}
+
+func TestOverridesBanned(t *testing.T) {
+	overrides := &Overrides{BannedVideos: []string{"bannedvideo"}, BannedChannels: []string{"UCbanned"}}
+	overrides.index()
+
+	tests := map[string]struct {
+		videoId, channelId string
+		want               bool
+	}{
+		"banned video":            {"bannedvideo", "UCallowed", true},
+		"banned video no channel": {"bannedvideo", "", true},
+		"banned channel":          {"othervideo1", "UCbanned", true},
+		"allowed":                 {"othervideo1", "UCallowed", false},
+		"unknown channel":         {"othervideo1", "", false},
+	}
+
+	for name, test := range tests {
+		if got := overrides.Banned(test.videoId, test.channelId); got != test.want {
+			t.Errorf("%s: expected [%v], got [%v]", name, test.want, got)
+		}
+	}
+}
+
+func TestOverridesNilIsEmpty(t *testing.T) {
+	var overrides *Overrides
+
+	if videoId, ok := overrides.Pinned(Track{SpotifyId: "spotifytrack1", ISRC: "GBAAA0000001"}); ok || videoId != "" {
+		t.Fatalf("expected no pin, got [%s]", videoId)
+	}
+	if overrides.Banned("bannedvideo", "UCbanned") {
+		t.Fatal("expected nothing to be banned")
+	}
+}
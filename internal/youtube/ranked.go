@@ -0,0 +1,98 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Factor is one part of the Score of a Ranked result
+type Factor struct {
+	Name  string
+	Value float64
+}
+
+// Ranked is a search result with the Score it is ranked by. The Score is the
+// product of its Factors. Views and Verified break ties between equal Scores.
+type Ranked[T any] struct {
+	Item      T
+	Score     float64
+	Breakdown []Factor
+	Views     int64
+	Verified  bool
+}
+
+// NewRanked returns an Item with a Score of 1, ready for its Factors to be
+// applied
+func NewRanked[T any](item T) *Ranked[T] {
+	return &Ranked[T]{Item: item, Score: 1}
+}
+
+// Apply multiplies the Score by a Factor, and records it in the Breakdown
+func (r *Ranked[T]) Apply(name string, value float64) {
+	r.Score *= value
+	r.Breakdown = append(r.Breakdown, Factor{Name: name, Value: value})
+}
+
+// Explain describes how the Score was reached, such as
+// "0.76 = similarity 0.76 × duration 1.00"
+func (r *Ranked[T]) Explain() string {
+	if len(r.Breakdown) == 0 {
+		return fmt.Sprintf("%.2f", r.Score)
+	}
+
+	factors := make([]string, 0, len(r.Breakdown))
+	for _, factor := range r.Breakdown {
+		factors = append(factors, fmt.Sprintf("%s %.2f", factor.Name, factor.Value))
+	}
+	return fmt.Sprintf("%.2f = %s", r.Score, strings.Join(factors, " × "))
+}
+
+// compareRanked orders the better of two results first: the higher Score,
+// then a Verified channel, then the most Views
+func compareRanked[T any](a, b *Ranked[T]) int {
+	if c := cmp.Compare(b.Score, a.Score); c != 0 {
+		return c
+	}
+	if a.Verified != b.Verified {
+		if a.Verified {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Compare(b.Views, a.Views)
+}
+
+// Rank sorts results from the best to the worst. Results which still tie keep
+// the order they were found in.
+func Rank[T any](results []*Ranked[T]) {
+	slices.SortStableFunc(results, compareRanked[T])
+}
+
+// TopK returns at most k of the best results, in order
+func TopK[T any](results []*Ranked[T], k int) []*Ranked[T] {
+	ranked := slices.Clone(results)
+	Rank(ranked)
+
+	if k < len(ranked) {
+		ranked = ranked[:k]
+	}
+	return ranked
+}
@@ -0,0 +1,77 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"slices"
+	"testing"
+)
+
+func ranked(name string, score float64, views int64, verified bool) *Ranked[string] {
+	result := NewRanked(name)
+	result.Apply("similarity", score)
+	result.Views, result.Verified = views, verified
+	return result
+}
+
+func TestRankBreaksTies(t *testing.T) {
+	results := []*Ranked[string]{
+		ranked("lyrics", 0.9, 5_000, false),
+		ranked("cover", 0.5, 9_000_000, true),
+		ranked("reupload", 0.9, 5_000, false),
+		ranked("popular", 0.9, 80_000, false),
+		ranked("official", 0.9, 1_000, true),
+	}
+
+	Rank(results)
+
+	var got []string
+	for _, result := range results {
+		got = append(got, result.Item)
+	}
+	if want := []string{"official", "popular", "lyrics", "reupload", "cover"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	results := []*Ranked[string]{ranked("c", 0.3, 0, false), ranked("a", 0.9, 0, false), ranked("b", 0.6, 0, false)}
+
+	top := TopK(results, 2)
+	if len(top) != 2 || top[0].Item != "a" || top[1].Item != "b" {
+		t.Fatalf("unexpected top results: %v, %v", top[0].Item, top[1].Item)
+	}
+	if results[0].Item != "c" {
+		t.Error("expected TopK to leave its input in order")
+	}
+	if got := TopK(results, 10); len(got) != 3 {
+		t.Errorf("expected every result when k is larger, got [%d]", len(got))
+	}
+}
+
+func TestRankedExplain(t *testing.T) {
+	result := NewRanked("video")
+	result.Apply("token-sort", 0.8)
+	result.Apply("duration", 0.5)
+
+	if result.Score != 0.4 {
+		t.Errorf("expected a score of 0.4, got [%v]", result.Score)
+	}
+	if got, want := result.Explain(), "0.40 = token-sort 0.80 × duration 0.50"; got != want {
+		t.Errorf("expected [%s], got [%s]", want, got)
+	}
+}
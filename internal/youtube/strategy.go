@@ -0,0 +1,100 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"errors"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+)
+
+const (
+	searchListCost = 100
+	videosListCost = 1
+	// searchCost is the most a single GetTrack can spend
+	searchCost = searchListCost + videosListCost
+)
+
+// ErrQuotaBudget is returned when a Data API search would take the Credits
+// used past the quota budget of the run
+var ErrQuotaBudget = errors.New("quota budget for searches reached")
+
+// FindTrack searches for a Track according to the search strategy of the run.
+// The hybrid strategy searches InnerTube first, and only spends Credits on a
+// Data API search when InnerTube fails or its best Candidate scores below the
+// review threshold, and the search fits within the quota budget.
+func (yt *YouTube) FindTrack(track Track, maxResults int64) (*Match, error) {
+	switch yt.strategy {
+	case config.StrategyInnerTube:
+		return yt.GetTrackUnofficial(track, maxResults)
+	case config.StrategyDataAPI:
+		if !yt.withinBudget() {
+			return nil, ErrQuotaBudget
+		}
+		return yt.GetTrack(track, maxResults)
+	}
+
+	match, err := yt.GetTrackUnofficial(track, maxResults)
+	if err == nil && !yt.lowConfidence(match) {
+		return match, nil
+	}
+
+	if !yt.withinBudget() {
+		logger.Debug("Not falling back to the Data API, the quota budget is spent", "track", track.Query(), "credits", yt.Credits)
+		return match, err
+	}
+
+	if err != nil {
+		logger.Warn("InnerTube search failed. Falling back to the Data API.", "track", track.Query(), "error", err)
+	} else {
+		logger.Info("Low confidence match. Falling back to the Data API.", "track", track.Query(), "score", bestScore(match))
+	}
+
+	apiMatch, apiErr := yt.GetTrack(track, maxResults)
+	if apiErr != nil {
+		if err != nil {
+			return nil, errors.Join(err, apiErr)
+		}
+		logger.Warn("Data API search failed. Keeping the InnerTube match.", "track", track.Query(), "error", apiErr)
+		return match, nil
+	}
+
+	if err != nil || bestScore(apiMatch) > bestScore(match) {
+		return apiMatch, nil
+	}
+	return match, nil
+}
+
+// lowConfidence reports whether a Match is worth a second opinion
+func (yt *YouTube) lowConfidence(match *Match) bool {
+	if match.Method == Pinned {
+		return false
+	}
+	return bestScore(match) < yt.fallbackBelow
+}
+
+// withinBudget reports whether a Data API search fits in the quota budget
+func (yt *YouTube) withinBudget() bool {
+	return yt.Credits+searchCost <= yt.quotaBudget
+}
+
+func bestScore(match *Match) float64 {
+	if best := match.Best(); best != nil {
+		return best.Score
+	}
+	return 0
+}
@@ -0,0 +1,113 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube/innertube"
+)
+
+// stubSearch answers every InnerTube search with the same videos, or fails
+type stubSearch struct {
+	videos []innertube.Video
+	err    error
+}
+
+func (s *stubSearch) Dispatch(_ string, _ map[string]string, _ map[string]interface{}) (map[string]interface{}, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	var contents []interface{}
+	for _, video := range s.videos {
+		contents = append(contents, map[string]interface{}{
+			"videoRenderer": map[string]interface{}{
+				"videoId": video.VideoId,
+				"title":   map[string]interface{}{"runs": []interface{}{map[string]interface{}{"text": video.Title}}},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"contents": map[string]interface{}{
+			"twoColumnSearchResultsRenderer": map[string]interface{}{
+				"primaryContents": map[string]interface{}{
+					"sectionListRenderer": map[string]interface{}{
+						"contents": []interface{}{
+							map[string]interface{}{"itemSectionRenderer": map[string]interface{}{"contents": contents}},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+func TestFindTrackStrategies(t *testing.T) {
+	track := Track{Artist: "Artist", Name: "Song"}
+	exact := []innertube.Video{{VideoId: "innertube01", Title: "Artist - Song"}}
+	vague := []innertube.Video{{VideoId: "innertube02", Title: "Artist - Song (Live at Wembley)"}}
+
+	tests := []struct {
+		name      string
+		strategy  string
+		budget    int
+		innertube *stubSearch
+		want      string
+		searches  int
+	}{
+		{"innertube only", config.StrategyInnerTube, 5000, &stubSearch{videos: vague}, "innertube02", 0},
+		{"api only", config.StrategyDataAPI, 5000, &stubSearch{videos: exact}, "apivideo001", 1},
+		{"hybrid confident", config.StrategyHybrid, 5000, &stubSearch{videos: exact}, "innertube01", 0},
+		{"hybrid low confidence", config.StrategyHybrid, 5000, &stubSearch{videos: vague}, "apivideo001", 1},
+		{"hybrid innertube error", config.StrategyHybrid, 5000, &stubSearch{err: errors.New("blocked")}, "apivideo001", 1},
+		{"hybrid over budget", config.StrategyHybrid, 100, &stubSearch{videos: vague}, "innertube02", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			yt, server := newTestYouTube(t)
+			yt.SetInnerTube(&innertube.InnerTube{Adaptor: test.innertube})
+			yt.SetInnerTubeMusic(nil)
+			yt.strategy, yt.quotaBudget = test.strategy, test.budget
+			server.AddVideo("apivideo001", "Artist - Song (Official Video)", "UCartist")
+
+			match, err := yt.FindTrack(track, 5)
+			if err != nil {
+				t.Fatalf("FindTrack: %v", err)
+			}
+			if best := match.Best(); best == nil || best.VideoId != test.want {
+				t.Errorf("expected [%s], got %+v", test.want, match.Candidates)
+			}
+			if got := server.Calls("search.list"); got != test.searches {
+				t.Errorf("expected [%d] Data API searches, got [%d]", test.searches, got)
+			}
+		})
+	}
+}
+
+func TestFindTrackQuotaBudget(t *testing.T) {
+	yt, _ := newTestYouTube(t)
+	yt.strategy, yt.quotaBudget = config.StrategyDataAPI, 50
+
+	if _, err := yt.FindTrack(Track{Artist: "Artist", Name: "Song"}, 5); !errors.Is(err, ErrQuotaBudget) {
+		t.Fatalf("expected ErrQuotaBudget, got %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var videoIdPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+
+// Track describes a Spotify Track which is to be found on YouTube
+type Track struct {
+	SpotifyId string `json:"spotifyId"`
+	ISRC      string `json:"isrc,omitempty"`
+	Artist    string `json:"artist"`
+	Name      string `json:"name"`
+	Album     string `json:"album,omitempty"`
+	Duration  int    `json:"durationMs,omitempty"`
+	// Position is the 1-based position of the Track on its Album when an
+	// Album is converted, or zero otherwise
+	Position int `json:"position,omitempty"`
+}
+
+// Query returns the text used to Search for the Track
+func (t Track) Query() string {
+	return fmt.Sprintf("%s %s", t.Artist, t.Name)
+}
+
+// Candidate is a YouTube video which may be a match for a Track
+type Candidate struct {
+	VideoId   string  `json:"videoId"`
+	Title     string  `json:"title"`
+	Channel   string  `json:"channel,omitempty"`
+	ChannelId string  `json:"channelId,omitempty"`
+	Length    string  `json:"length,omitempty"`
+	Score     float64 `json:"score"`
+	// Explanation describes how the Score was reached
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// URL returns the watch page of the Candidate
+func (c Candidate) URL() string {
+	return "https://www.youtube.com/watch?v=" + c.VideoId
+}
+
+// MatchMethod is how the Candidates of a Match were found
+type MatchMethod string
+
+const (
+	// Pinned Matches come from the Overrides file
+	Pinned MatchMethod = "pinned"
+	// ISRCLookup Matches were found by searching YouTube Music for the ISRC
+	ISRCLookup MatchMethod = "isrc"
+	// AlbumLookup Matches are the song at the same position of the Track's
+	// album on YouTube Music
+	AlbumLookup MatchMethod = "album"
+	// FuzzySearch Matches were found by searching for the artist and title
+	FuzzySearch MatchMethod = "search"
+	// DataAPISearch Matches were found by searching for the artist and title
+	// with the YouTube Data API, which costs Credits
+	DataAPISearch MatchMethod = "api"
+)
+
+// Match is the result of searching YouTube for a Track. Candidates are ordered
+// from the most to the least similar.
+type Match struct {
+	Track      Track
+	Method     MatchMethod
+	Candidates []Candidate
+}
+
+// pinnedMatch is the Match for a Track pinned to a video by the Overrides
+func pinnedMatch(track Track, videoId string) *Match {
+	logger.Info("Track is pinned", "track", track.Query(), "videoId", videoId)
+
+	return &Match{
+		Track:      track,
+		Method:     Pinned,
+		Candidates: []Candidate{{VideoId: videoId, Title: track.Query(), Score: 1}},
+	}
+}
+
+// Best returns the most similar Candidate, or nil if there were no results
+func (m *Match) Best() *Candidate {
+	if m == nil || len(m.Candidates) == 0 {
+		return nil
+	}
+	return &m.Candidates[0]
+}
+
+// Top returns at most n of the most similar Candidates
+func (m *Match) Top(n int) []Candidate {
+	if n > len(m.Candidates) {
+		n = len(m.Candidates)
+	}
+	return m.Candidates[:n]
+}
+
+// ParseVideoId extracts a Video ID from a YouTube URL, or accepts a bare ID
+func ParseVideoId(input string) (string, bool) {
+	input = strings.TrimSpace(input)
+	if videoIdPattern.MatchString(input) {
+		return input, true
+	}
+
+	u, err := url.Parse(input)
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+
+	var videoId string
+	switch host := strings.TrimPrefix(u.Host, "www."); host {
+	case "youtu.be":
+		videoId = strings.Trim(u.Path, "/")
+	case "youtube.com", "m.youtube.com", "music.youtube.com":
+		if v := u.Query().Get("v"); v != "" {
+			videoId = v
+		} else if rest, ok := strings.CutPrefix(u.Path, "/shorts/"); ok {
+			videoId = rest
+		}
+	}
+
+	if !videoIdPattern.MatchString(videoId) {
+		return "", false
+	}
+
+	return videoId, true
+}
@@ -17,27 +17,153 @@
 package youtube
 
 import (
-	"log"
-
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/logging"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/retry"
 	"github.com/Renegade-Master/spotify-playlist-converter/internal/util"
 	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube/innertube"
+	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
 
+var logger = logging.For("youtube")
+
 type YouTube struct {
-	client    *youtube.Service
-	intClient *innertube.InnerTube
-	Credits   int
+	client         *youtube.Service
+	httpClient     *http.Client
+	editClient     *innertube.InnerTube
+	insertMethod   string
+	metadata       *playlistMetadata
+	intClient      *innertube.InnerTube
+	musicClient    *innertube.InnerTube
+	isrcLookup     bool
+	retryPolicy    retry.Policy
+	overrides      *Overrides
+	similarity     util.Matcher
+	matchThreshold float64
+	strategy       string
+	fallbackBelow  float64
+	quotaBudget    int
+	albumThreshold float64
+	searchPages    int
+	duration       DurationWindow
+	Credits        int
 }
 
-func NewYouTube() *YouTube {
-	youtubeService := createYouTubeService()
+func NewYouTube(cfg *config.Config) *YouTube {
+	youtubeService, httpClient := createYouTubeService()
+	yt, err := newYouTube(cfg, youtubeService, httpClient)
+	if err != nil {
+		logging.Fatal(logger, "Error creating YouTube client", "error", err)
+	}
+
+	return yt
+}
+
+// NewYouTubeWithOptions creates a YouTube client from Data API client options
+// without authorising in the browser, such as to point it at another endpoint
+// with option.WithEndpoint.
+func NewYouTubeWithOptions(cfg *config.Config, opts ...option.ClientOption) (*YouTube, error) {
+	youtubeService, httpClient, err := newYouTubeService(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return newYouTube(cfg, youtubeService, httpClient)
+}
+
+func newYouTube(cfg *config.Config, youtubeService *youtube.Service, httpClient *http.Client) (*YouTube, error) {
 	innerTubeService, _ := innertube.NewInnerTube()
+	musicService, _ := innertube.NewInnerTubeMusic()
+	editService, _ := innertube.NewInnerTubeWithClient(httpClient)
+
+	metadata, err := newPlaylistMetadata(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	yt := &YouTube{
+		client:         youtubeService,
+		httpClient:     httpClient,
+		editClient:     editService,
+		insertMethod:   cfg.InsertMethod,
+		metadata:       metadata,
+		intClient:      innerTubeService,
+		musicClient:    musicService,
+		isrcLookup:     cfg.ISRCLookup,
+		retryPolicy:    retry.DefaultPolicy,
+		similarity:     cfg.Matcher(),
+		matchThreshold: cfg.MatchThreshold,
+		strategy:       cfg.SearchStrategy,
+		fallbackBelow:  cfg.ReviewThreshold,
+		quotaBudget:    cfg.QuotaBudget,
+		albumThreshold: cfg.AlbumVerifyThreshold,
+		searchPages:    cfg.SearchPages,
+		duration: DurationWindow{
+			Tolerance: time.Duration(cfg.DurationToleranceSeconds) * time.Second,
+			Reject:    time.Duration(cfg.DurationRejectSeconds) * time.Second,
+		},
+	}
+
+	if cfg.OverridesFile != "" {
+		overrides, err := LoadOverrides(cfg.OverridesFile)
+		if err != nil {
+			return nil, err
+		}
+		yt.overrides = overrides
+	}
+
+	return yt, nil
+}
+
+// SetInnerTube replaces the InnerTube client used for unofficial searches
+func (yt *YouTube) SetInnerTube(intClient *innertube.InnerTube) {
+	yt.intClient = intClient
+}
+
+// SetInnerTubeMusic replaces the InnerTube client used for YouTube Music
+// searches
+func (yt *YouTube) SetInnerTubeMusic(musicClient *innertube.InnerTube) {
+	yt.musicClient = musicClient
+}
+
+// SetInnerTubeEditor replaces the InnerTube client used to add videos to
+// Playlists. It must send requests with the user's OAuth token.
+func (yt *YouTube) SetInnerTubeEditor(editClient *innertube.InnerTube) {
+	yt.editClient = editClient
+}
+
+// SetOverrides replaces the pins and bans read from the overrides file
+func (yt *YouTube) SetOverrides(overrides *Overrides) {
+	overrides.index()
+	yt.overrides = overrides
+}
+
+// do executes a YouTube Data API call, retrying transient failures
+func (yt *YouTube) do(call func() error) error {
+	return retry.Do(yt.retryPolicy, call)
+}
 
-	return &YouTube{
-		client:    youtubeService,
-		intClient: innerTubeService,
+// doInsert executes a YouTube Data API call which must not be applied twice.
+// Before a call which may have been applied is repeated, applied checks
+// whether it took effect.
+func (yt *YouTube) doInsert(call func() error, applied func() bool) error {
+	return retry.DoNonIdempotent(yt.retryPolicy, call, applied)
+}
+
+// hasVideo reports whether a Playlist holds a video
+func (yt *YouTube) hasVideo(playlistId, videoId string) bool {
+	for _, item := range yt.GetPlaylistItems(playlistId) {
+		if item.Snippet.ResourceId.VideoId == videoId {
+			return true
+		}
 	}
+	return false
 }
 
 func (yt *YouTube) ListChannels() {
@@ -45,22 +171,27 @@ func (yt *YouTube) ListChannels() {
 		Mine(true).
 		MaxResults(50)
 
-	response, err := call.Do()
+	var response *youtube.ChannelListResponse
+	err := yt.do(func() (err error) {
+		response, err = call.Do()
+		return err
+	})
 	if err != nil {
-		log.Fatalf("Error retrieving channels: %v", err)
+		logging.Fatal(logger, "Error retrieving channels", "error", err)
 	}
 	yt.Credits += 1
 
 	if len(response.Items) == 0 {
-		log.Println("No channels found.")
+		logger.Info("No channels found")
 	} else {
 		for i, channel := range response.Items {
-			log.Printf("%d. %s\n", i+1, channel.Snippet.Title)
-			log.Printf("   ID: %s\n", channel.Id)
-			log.Printf("   Title: %s\n", channel.Snippet.Title)
-			log.Printf("   Description: %s\n", channel.Snippet.Description)
-			log.Printf("   Custom URL: %s\n", channel.Snippet.CustomUrl)
-			log.Println()
+			logger.Info("Channel",
+				"index", i+1,
+				"id", channel.Id,
+				"title", channel.Snippet.Title,
+				"description", channel.Snippet.Description,
+				"customUrl", channel.Snippet.CustomUrl,
+			)
 		}
 	}
 }
@@ -69,14 +200,16 @@ func (yt *YouTube) ListPlaylists() {
 	playlists := yt.GetPlaylists()
 
 	if len(playlists) == 0 {
-		log.Println("No playlists found.")
+		logger.Info("No playlists found")
 	} else {
 		for i, playlist := range playlists {
-			log.Printf("%d. %s\n", i+1, playlist.Snippet.Title)
-			log.Printf("   ID: %s\n", playlist.Id)
-			log.Printf("   Description: %s\n", playlist.Snippet.Description)
-			log.Printf("   Videos: %d\n", playlist.ContentDetails.ItemCount)
-			log.Println()
+			logger.Info("Playlist",
+				"index", i+1,
+				"id", playlist.Id,
+				"title", playlist.Snippet.Title,
+				"description", playlist.Snippet.Description,
+				"videos", playlist.ContentDetails.ItemCount,
+			)
 		}
 	}
 }
@@ -91,14 +224,18 @@ func (yt *YouTube) GetPlaylists() []*youtube.Playlist {
 			MaxResults(50).
 			PageToken(nextPageToken)
 
-		response, err := call.Do()
+		var response *youtube.PlaylistListResponse
+		err := yt.do(func() (err error) {
+			response, err = call.Do()
+			return err
+		})
 		if err != nil {
-			log.Fatalf("Error retrieving Playlists: %v", err)
+			logging.Fatal(logger, "Error retrieving Playlists", "error", err)
 		}
 		yt.Credits += 1
 
 		if len(response.Items) == 0 {
-			log.Println("No Playlists found.")
+			logger.Debug("No Playlists found")
 		} else {
 			playlists = append(playlists, response.Items...)
 		}
@@ -117,7 +254,7 @@ func (yt *YouTube) GetPlaylist(playlistId string) *youtube.Playlist {
 	playlists := yt.GetPlaylists()
 
 	if len(playlists) == 0 {
-		log.Println("No Playlists found.")
+		logger.Debug("No Playlists found")
 	} else {
 		for _, playlist := range playlists {
 			if playlist.Id == playlistId {
@@ -139,14 +276,18 @@ func (yt *YouTube) GetPlaylistItems(playlistId string) []*youtube.PlaylistItem {
 			MaxResults(50).
 			PageToken(nextPageToken)
 
-		response, err := call.Do()
+		var response *youtube.PlaylistItemListResponse
+		err := yt.do(func() (err error) {
+			response, err = call.Do()
+			return err
+		})
 		if err != nil {
-			log.Fatalf("Error retrieving Tracks: [%v]", err)
+			logging.Fatal(logger, "Error retrieving Tracks", "playlistId", playlistId, "error", err)
 		}
 		yt.Credits += 1
 
 		if len(response.Items) == 0 {
-			log.Println("No Tracks found.")
+			logger.Debug("No Tracks found", "playlistId", playlistId)
 		} else {
 			playlistItems = append(playlistItems, response.Items...)
 		}
@@ -161,162 +302,418 @@ func (yt *YouTube) GetPlaylistItems(playlistId string) []*youtube.PlaylistItem {
 	return playlistItems
 }
 
-// GetTrackUnofficial is a method of Searching YouTube without using Credits
-func (yt *YouTube) GetTrackUnofficial(query string, maxResults int64) string {
+// GetTrackUnofficial is a method of Searching YouTube without using Credits.
+// Videos are scored against the Track until maxResults acceptable Candidates
+// are found, following the search onto later pages when the first does not
+// have enough. The Candidates are returned from the most to the least similar.
+func (yt *YouTube) GetTrackUnofficial(track Track, maxResults int64) (*Match, error) {
+	if videoId, ok := yt.overrides.Pinned(track); ok {
+		return pinnedMatch(track, videoId), nil
+	}
+
+	if match := yt.getTrackByISRC(track); match != nil {
+		return match, nil
+	}
+
 	paramsTypeVideo := "EgIQAQ%3D%3D"
+	query := track.Query()
 
-	data, err := yt.intClient.Search(&query, &paramsTypeVideo, nil)
-	if err != nil {
-		log.Fatalf("Error retrieving track: %s", err)
+	results := yt.intClient.SearchVideos(query, paramsTypeVideo, yt.searchPages)
+
+	var ranked []*Ranked[innertube.Video]
+	for len(ranked) < int(maxResults) {
+		video, ok := results.Next()
+		if !ok {
+			break
+		}
+
+		logger.Debug("Found Track", "title", video.Title, "videoId", video.VideoId)
+
+		if yt.overrides.Banned(video.VideoId, video.ChannelId) {
+			logger.Info("Ignoring banned video", "videoId", video.VideoId, "channel", video.Channel)
+			continue
+		}
+
+		result := NewRanked(video)
+		result.Views, result.Verified = video.Views, video.Verified
+		result.Apply(string(yt.similarity.Algorithm), yt.trackSimilarity(track, video.Title))
+		if length, ok := video.Duration(); ok {
+			weight, accepted := yt.duration.Weight(trackDuration(track), length)
+			if !accepted {
+				logger.Debug("Ignoring video outside the duration window", "title", video.Title, "length", video.Length)
+				continue
+			}
+			result.Apply("duration", weight)
+		}
+		if result.Score < yt.matchThreshold {
+			logger.Debug("Ignoring dissimilar video", "title", video.Title, "score", result.Explain())
+			continue
+		}
+
+		ranked = append(ranked, result)
+	}
+
+	if err := results.Err(); err != nil {
+		if results.Pages() == 0 {
+			return nil, fmt.Errorf("error retrieving track [%s]: %w", query, err)
+		}
+		logger.Warn("Error retrieving more search results", "track", query, "page", results.Pages()+1, "error", err)
 	}
 
-	contents := data["contents"].(map[string]interface{})["twoColumnSearchResultsRenderer"].(map[string]interface{})["primaryContents"].(map[string]interface{})["sectionListRenderer"].(map[string]interface{})["contents"]
-	resultsContent := contents.([]interface{})[0].(map[string]interface{})["itemSectionRenderer"].(map[string]interface{})["contents"]
-	results := resultsContent.([]interface{})
+	ranked = TopK(ranked, int(maxResults))
+
+	match := &Match{Track: track, Method: FuzzySearch}
+	for _, result := range ranked {
+		video := result.Item
+		logger.Debug("Ranked video", "title", video.Title, "videoId", video.VideoId, "score", result.Explain())
+
+		match.Candidates = append(match.Candidates, Candidate{
+			VideoId:     video.VideoId,
+			Title:       video.Title,
+			Channel:     video.Channel,
+			ChannelId:   video.ChannelId,
+			Length:      video.Length,
+			Score:       result.Score,
+			Explanation: result.Explain(),
+		})
+	}
+
+	return match, nil
+}
+
+// trackSimilarity scores a YouTube title against the Track, crediting any
+// artists the Track features alongside its artist
+func (yt *YouTube) trackSimilarity(track Track, title string) float64 {
+	return yt.similarity.TrackSimilarity(track.Artist, track.Name, title)
+}
+
+// getTrackByISRC searches YouTube Music for the ISRC of the Track, which
+// surfaces the recording uploaded to the artist's Topic channel. The top song
+// is only accepted if it resembles the Track, as an ISRC YouTube Music does
+// not know returns unrelated songs. Returns nil when nothing usable is found,
+// so that the caller falls back to searching by title.
+func (yt *YouTube) getTrackByISRC(track Track) *Match {
+	if !yt.isrcLookup || track.ISRC == "" || yt.musicClient == nil {
+		return nil
+	}
 
-	// Iterate through the first maxResults results
-	var weightedTracks []WeightedSimpleSearchResult
-	for i := 0; i < int(maxResults); i++ {
-		result := results[i].(map[string]interface{})["videoRenderer"].(map[string]interface{})
-		title := result["title"].(map[string]interface{})["runs"].([]interface{})[0].(map[string]interface{})["text"].(string)
-		videoId := result["videoId"].(string)
+	isrc := strings.ToUpper(track.ISRC)
+	paramsTypeSong := innertube.MusicSongsParams
 
-		log.Printf("Found Track: [%s] [%s]\n", title, videoId)
+	data, err := yt.musicClient.Search(&isrc, &paramsTypeSong, nil)
+	if err != nil {
+		logger.Warn("ISRC lookup failed. Falling back to search.", "track", track.Query(), "isrc", isrc, "error", err)
+		return nil
+	}
 
-		distance := util.LevenshteinDistance(query, title)
-		weightedTracks = append(weightedTracks, WeightedSimpleSearchResult{Result: title, Weight: distance, Id: videoId})
+	songs := innertube.ParseMusicSearch(data)
+	if len(songs) == 0 {
+		logger.Debug("No songs found by ISRC", "track", track.Query(), "isrc", isrc)
+		return nil
 	}
 
-	distance := func(track1, track2 *WeightedSimpleSearchResult) bool {
-		return track1.Weight < track2.Weight
+	song := songs[0]
+	if yt.overrides.Banned(song.VideoId, song.ChannelId) {
+		logger.Info("Ignoring banned video", "videoId", song.VideoId, "channel", song.Channel)
+		return nil
 	}
 
-	BySimpleSearchResult(distance).SortSimpleSearchResult(weightedTracks)
+	score := yt.trackSimilarity(track, song.Channel+" "+song.Title)
+	if length, ok := song.Duration(); ok {
+		weight, accepted := yt.duration.Weight(trackDuration(track), length)
+		if !accepted {
+			logger.Info("ISRC lookup found a song of the wrong length", "track", track.Query(), "title", song.Title, "length", song.Length)
+			return nil
+		}
+		score *= weight
+	}
+	if score < yt.matchThreshold {
+		logger.Info("ISRC lookup found a different song", "track", track.Query(), "title", song.Title, "artist", song.Channel, "score", score)
+		return nil
+	}
 
-	// Return the top (i.e. most similar) Result
-	return weightedTracks[0].Id
+	logger.Debug("Found Track by ISRC", "track", track.Query(), "isrc", isrc, "videoId", song.VideoId)
+
+	return &Match{
+		Track:  track,
+		Method: ISRCLookup,
+		Candidates: []Candidate{{
+			VideoId:   song.VideoId,
+			Title:     song.Title,
+			Channel:   song.Channel,
+			ChannelId: song.ChannelId,
+			Length:    song.Length,
+			Score:     score,
+		}},
+	}
 }
 
-func (yt *YouTube) GetTrack(query string, maxResults int64) *youtube.SearchResult {
-	log.Printf("Searching for: [%s]\n", query)
+// GetTrack Searches YouTube with the Data API, costing 100 Credits, plus one
+// more to look up the video lengths. The videos are scored against the Track
+// in the same way as GetTrackUnofficial.
+func (yt *YouTube) GetTrack(track Track, maxResults int64) (*Match, error) {
+	if videoId, ok := yt.overrides.Pinned(track); ok {
+		return pinnedMatch(track, videoId), nil
+	}
+
+	query := track.Query()
+	logger.Info("Searching", "query", query)
 
 	call := yt.client.Search.List([]string{"snippet"}).
 		Q(query).
+		Type("video").
 		MaxResults(maxResults)
 
-	response, err := call.Do()
+	var response *youtube.SearchListResponse
+	err := yt.do(func() (err error) {
+		response, err = call.Do()
+		return err
+	})
 	if err != nil {
-		log.Fatalf("Error retrieving track: %s", err)
+		return nil, fmt.Errorf("error retrieving track [%s]: %w", query, err)
 	}
-	yt.Credits += 100
+	yt.Credits += searchListCost
 
+	match := &Match{Track: track, Method: DataAPISearch}
 	if len(response.Items) == 0 {
-		log.Println("No tracks found.")
-		return &youtube.SearchResult{}
-	} else {
-		var weightedTracks []WeightedSearchResult
-		for _, track := range response.Items {
-			log.Printf("Found Track: [%s]\n", track.Snippet.Title)
-			youTubeTitle := track.Snippet.Title
+		logger.Info("No tracks found", "query", query)
+		return match, nil
+	}
+
+	durations := yt.getVideoDurations(track, response.Items)
+
+	var ranked []*Ranked[*youtube.SearchResult]
+	for _, item := range response.Items {
+		if item.Id == nil || item.Snippet == nil {
+			continue
+		}
+
+		logger.Debug("Found Track", "title", item.Snippet.Title, "videoId", item.Id.VideoId)
+		youTubeTitle := item.Snippet.Title
 
-			distance := util.LevenshteinDistance(query, youTubeTitle)
-			weightedTracks = append(weightedTracks, WeightedSearchResult{Result: track, Weight: distance})
+		if yt.overrides.Banned(item.Id.VideoId, item.Snippet.ChannelId) {
+			logger.Info("Ignoring banned video", "videoId", item.Id.VideoId, "channel", item.Snippet.ChannelTitle)
+			continue
 		}
 
-		distance := func(track1, track2 *WeightedSearchResult) bool {
-			return track1.Weight < track2.Weight
+		result := NewRanked(item)
+		result.Apply(string(yt.similarity.Algorithm), yt.trackSimilarity(track, youTubeTitle))
+		if length, ok := durations[item.Id.VideoId]; ok {
+			weight, accepted := yt.duration.Weight(trackDuration(track), length)
+			if !accepted {
+				logger.Debug("Ignoring video outside the duration window", "title", youTubeTitle, "videoId", item.Id.VideoId)
+				continue
+			}
+			result.Apply("duration", weight)
+		}
+		if result.Score < yt.matchThreshold {
+			logger.Debug("Ignoring dissimilar video", "title", youTubeTitle, "score", result.Explain())
+			continue
 		}
 
-		BySearchResult(distance).SortSearchResult(weightedTracks)
+		ranked = append(ranked, result)
+	}
 
-		// Return the top (i.e. most similar) Result
-		return weightedTracks[0].Result
+	if len(ranked) == 0 {
+		logger.Info("All tracks found are banned, dissimilar or the wrong length", "query", query)
+		return match, nil
 	}
+
+	ranked = TopK(ranked, int(maxResults))
+
+	for _, result := range ranked {
+		item := result.Item
+		logger.Debug("Ranked video", "title", item.Snippet.Title, "videoId", item.Id.VideoId, "score", result.Explain())
+
+		candidate := Candidate{
+			VideoId:     item.Id.VideoId,
+			Title:       item.Snippet.Title,
+			Channel:     item.Snippet.ChannelTitle,
+			ChannelId:   item.Snippet.ChannelId,
+			Score:       result.Score,
+			Explanation: result.Explain(),
+		}
+		if length, ok := durations[item.Id.VideoId]; ok {
+			candidate.Length = FormatLength(length)
+		}
+		match.Candidates = append(match.Candidates, candidate)
+	}
+
+	return match, nil
 }
 
-// CreatePlaylist will create a YouTube Playlist if it does not already exist.
-// Returns the Playlist ID of the new Playlist, or the existing Playlist by the
-// same name, as well as a Boolean to indicate if this is a new Playlist.
-func (yt *YouTube) CreatePlaylist(name string) (string, bool) {
-	playlists := yt.GetPlaylists()
-	for _, playlist := range playlists {
-		if playlist.Snippet.Title == name {
-			log.Printf("Playlist [%s] already exists\n", name)
-			return playlist.Id, false
+func (yt *YouTube) getVideoDurations(track Track, results []*youtube.SearchResult) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	if trackDuration(track) <= 0 || yt.duration.Reject <= 0 {
+		return durations
+	}
+
+	var videoIds []string
+	for _, result := range results {
+		if result.Id != nil && result.Id.VideoId != "" {
+			videoIds = append(videoIds, result.Id.VideoId)
 		}
 	}
+	if len(videoIds) == 0 {
+		return durations
+	}
 
-	playlist := &youtube.Playlist{
-		Snippet: &youtube.PlaylistSnippet{
-			Title:       name,
-			Description: "Playlist created by Spotify Playlist Converter",
-			Tags:        []string{"spotify-playlist-converter"},
-		},
-		Status: &youtube.PlaylistStatus{
-			PrivacyStatus: "private",
-		},
+	call := yt.client.Videos.List([]string{"contentDetails"}).Id(videoIds...)
+
+	var response *youtube.VideoListResponse
+	err := yt.do(func() (err error) {
+		response, err = call.Do()
+		return err
+	})
+	if err != nil {
+		logger.Warn("Unable to retrieve video durations", "error", err)
+		return durations
 	}
+	yt.Credits += videosListCost
+
+	for _, video := range response.Items {
+		if video.ContentDetails == nil {
+			continue
+		}
+		if duration, ok := ParseISODuration(video.ContentDetails.Duration); ok {
+			durations[video.Id] = duration
+		}
+	}
+
+	return durations
+}
 
-	call := yt.client.Playlists.Insert([]string{"snippet", "status"}, playlist)
-	response, err := call.Do()
+// FindPlaylist returns the ID of the YouTube Playlist converted from the
+// source, or an empty string if there is none. The Playlist is found by
+// linkedId, the YouTube Playlist the source was last converted to, or by the
+// marker of the source in its description, and is renamed to follow the
+// source.
+func (yt *YouTube) FindPlaylist(source PlaylistSource, linkedId string) string {
+	playlist := findConverted(yt.GetPlaylists(), source, linkedId, yt.PlaylistTitle(source))
+	if playlist == nil {
+		return ""
+	}
+
+	logger.Info("Playlist already exists", "name", playlist.Snippet.Title, "playlistId", playlist.Id)
+	yt.syncMetadata(playlist, source)
+	return playlist.Id
+}
+
+// CreatePlaylist will create a YouTube Playlist if FindPlaylist does not find
+// one already converted from the source. The title, description, privacy and
+// language are taken from the templates and settings of the run. Returns the
+// Playlist ID of the new or existing Playlist, as well as a Boolean to
+// indicate if this is a new Playlist.
+func (yt *YouTube) CreatePlaylist(source PlaylistSource, linkedId string) (string, bool) {
+	if playlistId := yt.FindPlaylist(source, linkedId); playlistId != "" {
+		return playlistId, false
+	}
+	name := yt.PlaylistTitle(source)
+
+	source.Date = time.Now().Format(time.DateOnly)
+	call := yt.client.Playlists.Insert([]string{"snippet", "status"}, yt.newPlaylist(source))
+
+	var response *youtube.Playlist
+	var appliedId string
+	err := yt.doInsert(func() (err error) {
+		response, err = call.Do()
+		return err
+	}, func() bool {
+		appliedId = yt.FindPlaylist(source, linkedId)
+		return appliedId != ""
+	})
 	if err != nil {
-		log.Fatalf("Error creating Playlist: %v", err)
+		logging.Fatal(logger, "Error creating Playlist", "name", name, "error", err)
 	}
 	yt.Credits += 50
 
-	log.Printf("Created Playlist: [%s], ID: [%s]\n", response.Snippet.Title, response.Id)
+	if response == nil {
+		logger.Info("Created Playlist", "name", name, "playlistId", appliedId)
+		return appliedId, true
+	}
+	logger.Info("Created Playlist", "name", response.Snippet.Title, "playlistId", response.Id)
 	return response.Id, true
 }
 
-func (yt *YouTube) AddToPlaylist(playlistId string, trackIds ...string) error {
+// InsertResult is the outcome of adding one video to a Playlist. A video that
+// was neither a Duplicate nor failed with Err was inserted.
+type InsertResult struct {
+	VideoId   string
+	Duplicate bool
+	Err       error
+}
+
+// AddToPlaylist adds videos to a Playlist, skipping any that are already
+// present. A result is returned for every video, in the order given. Videos
+// which cannot be added are skipped, but a terminal error stops the insertion
+// and is also returned. The videos are sent according to the insert method of
+// the run: one call each, in batches, or through InnerTube.
+func (yt *YouTube) AddToPlaylist(playlistId string, trackIds ...string) ([]InsertResult, error) {
 	playlistItems := yt.GetPlaylistItems(playlistId)
 
 	// Check if any Tracks already exist in the Playlist
-	var badTrackIds []int
+	existing := make(map[string]bool)
 	for _, playlistItem := range playlistItems {
-		for idx, trackId := range trackIds {
-			if playlistItem.Snippet.ResourceId.VideoId == trackId {
-				log.Printf("Track [%s] already exists in Playlist [%s]\n", trackId, playlistId)
-				badTrackIds = append(badTrackIds, idx)
-			}
-		}
+		existing[playlistItem.Snippet.ResourceId.VideoId] = true
 	}
 
-	// Remove Tracks that already exist
-	if len(badTrackIds) == len(trackIds) {
-		log.Printf("All Tracks are already present in the Playlist")
-		return nil
-	}
+	results := make([]InsertResult, len(trackIds))
+	var pending []int
+	for idx, trackId := range trackIds {
+		results[idx].VideoId = trackId
 
-	itemsRemoved := 0
-	log.Printf("Removing [%d] Tracks that already exist in Playlist [%s]\n", len(badTrackIds), playlistId)
-	for _, idx := range badTrackIds {
-		trackIds = util.RemoveIndexString(trackIds, idx-itemsRemoved)
-		itemsRemoved++
+		if existing[trackId] {
+			logger.Debug("Track already exists in Playlist", "videoId", trackId, "playlistId", playlistId)
+			results[idx].Duplicate = true
+			continue
+		}
+		existing[trackId] = true
+		pending = append(pending, idx)
 	}
 
+	insert, chunkSize := yt.inserter()
+
+	var terminalErr error
+	inserted := 0
+
 	// Add all found Tracks to the Playlist
-	for _, trackId := range trackIds {
-		// ToDo: It would be nice if it was possible to add all Tracks in one call. May be possible using raw HTTP Requests instead of the library
-		call := yt.client.PlaylistItems.Insert([]string{"snippet"}, &youtube.PlaylistItem{
-			Snippet: &youtube.PlaylistItemSnippet{
-				PlaylistId: playlistId,
-				ResourceId: &youtube.ResourceId{
-					Kind:    "youtube#video",
-					VideoId: trackId,
-				},
-			},
-		})
+	for start := 0; start < len(pending); start += chunkSize {
+		chunk := pending[start:min(start+chunkSize, len(pending))]
 
-		_, err := call.Do()
-		if err != nil {
-			log.Printf("Error adding Track ID [%s] to Playlist [%s]: [%v]", trackId, playlistId, err)
-			return err
+		if terminalErr != nil {
+			for _, idx := range chunk {
+				results[idx].Err = terminalErr
+			}
+			continue
+		}
+
+		videoIds := make([]string, len(chunk))
+		for i, idx := range chunk {
+			videoIds[i] = trackIds[idx]
 		}
-		yt.Credits += 50
 
-		log.Printf("Added Track ID [%s] to Playlist [%s]\n", trackId, playlistId)
+		for i, err := range insert(playlistId, videoIds) {
+			trackId := videoIds[i]
+			if err != nil {
+				results[chunk[i]].Err = err
+
+				if retry.Classify(err) == retry.Skip {
+					logger.Warn("Skipping Track which cannot be added to Playlist", "videoId", trackId, "playlistId", playlistId, "error", err)
+					continue
+				}
+
+				logger.Error("Error adding Track to Playlist", "videoId", trackId, "playlistId", playlistId, "error", err)
+				if terminalErr == nil {
+					terminalErr = err
+				}
+				continue
+			}
+			inserted++
+
+			logger.Info("Added Track to Playlist", "videoId", trackId, "playlistId", playlistId)
+		}
 	}
 
-	return nil
+	logger.Info("Finished adding Tracks to Playlist", "inserted", inserted, "requested", len(trackIds), "playlistId", playlistId)
+	return results, terminalErr
 }
@@ -0,0 +1,672 @@
+/*
+ *    Copyright (c) 2026 [renegade@renegade-master.com]
+ *
+ *    Licensed under the Apache License, Version 2.0 (the "License");
+ *    you may not use this file except in compliance with the License.
+ *    You may obtain a copy of the License at
+ *
+ *        http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *    Unless required by applicable law or agreed to in writing, software
+ *    distributed under the License is distributed on an "AS IS" BASIS,
+ *    WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *    See the License for the specific language governing permissions and
+ *    limitations under the License.
+ */
+
+package youtube
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/config"
+	"github.com/Renegade-Master/spotify-playlist-converter/internal/youtube/fakeyoutube"
+	"google.golang.org/api/option"
+)
+
+func newTestYouTube(t *testing.T) (*YouTube, *fakeyoutube.Server) {
+	t.Helper()
+
+	return newTestYouTubeWithConfig(t, config.Default())
+}
+
+func newTestYouTubeWithConfig(t *testing.T, cfg *config.Config) (*YouTube, *fakeyoutube.Server) {
+	t.Helper()
+
+	server := fakeyoutube.New()
+	t.Cleanup(server.Close)
+
+	yt, err := NewYouTubeWithOptions(cfg, option.WithEndpoint(server.URL()), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("NewYouTubeWithOptions: %v", err)
+	}
+	yt.retryPolicy.Sleep = func(time.Duration) {}
+
+	return yt, server
+}
+
+func TestCreatePlaylist(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	playlistId, isNew := yt.CreatePlaylist(PlaylistSource{Name: "Road Trip"}, "")
+	if !isNew {
+		t.Fatal("expected a new Playlist")
+	}
+
+	again, isNew := yt.CreatePlaylist(PlaylistSource{Name: "Road Trip"}, "")
+	if isNew || again != playlistId {
+		t.Fatalf("expected existing Playlist [%s], got [%s] new [%v]", playlistId, again, isNew)
+	}
+
+	playlists := server.Playlists()
+	if len(playlists) != 1 || playlists[0].Status.PrivacyStatus != "private" {
+		t.Fatalf("unexpected Playlists: %+v", playlists)
+	}
+
+	// One list and one insert, then one more list
+	if yt.Credits != 52 || server.QuotaUsed() != 52 {
+		t.Fatalf("expected 52 credits, counted [%d], charged [%d]", yt.Credits, server.QuotaUsed())
+	}
+}
+
+func TestCreatePlaylistRendersMetadata(t *testing.T) {
+	cfg := config.Default()
+	cfg.PlaylistTitle = "{{.Name}} (from {{.Owner}})"
+	cfg.PlaylistDescription = "{{.Description}}\n\nConverted from <{{.URL}}> on {{.Date}}"
+	cfg.PlaylistPrivacy = config.PrivacyUnlisted
+	cfg.PlaylistLanguage = "en"
+	yt, server := newTestYouTubeWithConfig(t, cfg)
+
+	source := PlaylistSource{
+		SpotifyId:   "37i9dQZF1DXcBWIGoYBM5M",
+		Name:        "Road Trip",
+		Description: "Songs for the motorway",
+		Owner:       "Fake User",
+		URL:         "https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M",
+	}
+	yt.CreatePlaylist(source, "")
+
+	playlist := server.Playlists()[0]
+	want := "Songs for the motorway\n\nConverted from https://open.spotify.com/playlist/37i9dQZF1DXcBWIGoYBM5M on " + time.Now().Format(time.DateOnly) +
+		"\n\n[spotify:37i9dQZF1DXcBWIGoYBM5M]"
+	if playlist.Snippet.Title != "Road Trip (from Fake User)" || playlist.Snippet.Description != want {
+		t.Fatalf("unexpected metadata: %q %q", playlist.Snippet.Title, playlist.Snippet.Description)
+	}
+	if playlist.Status.PrivacyStatus != "unlisted" || playlist.Snippet.DefaultLanguage != "en" {
+		t.Fatalf("unexpected privacy [%s] or language [%s]", playlist.Status.PrivacyStatus, playlist.Snippet.DefaultLanguage)
+	}
+}
+
+func TestCreatePlaylistUpdatesDescription(t *testing.T) {
+	cfg := config.Default()
+	cfg.PlaylistDescription = "{{.Description}}"
+	cfg.UpdateMetadata = true
+	yt, server := newTestYouTubeWithConfig(t, cfg)
+
+	source := PlaylistSource{Name: "Road Trip", Description: "Songs for the motorway"}
+	playlistId, _ := yt.CreatePlaylist(source, "")
+
+	// An unchanged description is left alone
+	yt.CreatePlaylist(source, "")
+	if calls := server.Calls("playlists.update"); calls != 0 {
+		t.Fatalf("expected no updates, got [%d]", calls)
+	}
+
+	source.Description = "Songs for the coast road"
+	if again, isNew := yt.CreatePlaylist(source, ""); isNew || again != playlistId {
+		t.Fatalf("expected existing Playlist [%s], got [%s] new [%v]", playlistId, again, isNew)
+	}
+
+	playlist := server.Playlists()[0]
+	if playlist.Snippet.Description != "Songs for the coast road" || playlist.Snippet.Title != "Road Trip" {
+		t.Fatalf("unexpected metadata: %q %q", playlist.Snippet.Title, playlist.Snippet.Description)
+	}
+	if calls := server.Calls("playlists.update"); calls != 1 {
+		t.Fatalf("expected 1 update, got [%d]", calls)
+	}
+}
+
+func TestCreatePlaylistFindsRenamedSource(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	source := PlaylistSource{SpotifyId: "roadtrip00000000000001", Name: "Road Trip"}
+	playlistId, _ := yt.CreatePlaylist(source, "")
+
+	// Another source with the same name is a different Playlist
+	other, isNew := yt.CreatePlaylist(PlaylistSource{SpotifyId: "roadtrip00000000000002", Name: "Road Trip"}, "")
+	if !isNew || other == playlistId {
+		t.Fatalf("expected a second Playlist, got [%s] new [%v]", other, isNew)
+	}
+
+	source.Name = "Summer Road Trip"
+	if again, isNew := yt.CreatePlaylist(source, ""); isNew || again != playlistId {
+		t.Fatalf("expected existing Playlist [%s], got [%s] new [%v]", playlistId, again, isNew)
+	}
+
+	playlist := server.Playlists()[0]
+	if playlist.Snippet.Title != "Summer Road Trip" || markerOf(playlist.Snippet.Description) != source.SpotifyId {
+		t.Fatalf("expected the Playlist to be renamed, got %q %q", playlist.Snippet.Title, playlist.Snippet.Description)
+	}
+}
+
+func TestCreatePlaylistAdoptsLegacyPlaylists(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	legacy := server.AddPlaylist("Road Trip")
+	linked := server.AddPlaylist("Old Name")
+
+	// A Playlist made before markers is found by its title, and marked
+	source := PlaylistSource{SpotifyId: "roadtrip00000000000001", Name: "Road Trip"}
+	if playlistId, isNew := yt.CreatePlaylist(source, ""); isNew || playlistId != legacy {
+		t.Fatalf("expected Playlist [%s], got [%s] new [%v]", legacy, playlistId, isNew)
+	}
+	if description := server.Playlists()[0].Snippet.Description; markerOf(description) != source.SpotifyId {
+		t.Fatalf("expected a marker, got %q", description)
+	}
+
+	// The link table is checked first
+	source = PlaylistSource{SpotifyId: "workout000000000000001", Name: "Workout"}
+	if playlistId, isNew := yt.CreatePlaylist(source, linked); isNew || playlistId != linked {
+		t.Fatalf("expected Playlist [%s], got [%s] new [%v]", linked, playlistId, isNew)
+	}
+	if title := server.Playlists()[1].Snippet.Title; title != "Workout" {
+		t.Fatalf("expected the linked Playlist to be renamed, got %q", title)
+	}
+}
+
+func TestNewYouTubeRejectsUnknownTemplateFields(t *testing.T) {
+	cfg := config.Default()
+	cfg.PlaylistTitle = "{{.Artist}}"
+
+	if _, err := NewYouTubeWithOptions(cfg, option.WithoutAuthentication()); err == nil {
+		t.Fatal("expected an error for an unknown template field")
+	}
+}
+
+func TestNewYouTubeRejectsDateInTitle(t *testing.T) {
+	cfg := config.Default()
+	cfg.PlaylistTitle = `{{define "dated"}}{{.Name}} {{.Date}}{{end}}{{template "dated" .}}`
+
+	_, err := NewYouTubeWithOptions(cfg, option.WithoutAuthentication())
+	if err == nil || !strings.Contains(err.Error(), "must not use .Date") {
+		t.Fatalf("expected an error for a title using .Date, got %v", err)
+	}
+}
+
+func TestSetPlaylistImage(t *testing.T) {
+	yt, server := newTestYouTube(t)
+	playlistId := server.AddPlaylist("Road Trip")
+
+	// A wide image has its sides cropped off
+	wide := image.NewRGBA(image.Rect(0, 0, 400, 100))
+	draw.Draw(wide, wide.Bounds(), image.NewUniform(color.RGBA{B: 255, A: 255}), image.Point{}, draw.Src)
+	draw.Draw(wide, image.Rect(111, 0, 289, 100), image.NewUniform(color.RGBA{R: 255, A: 255}), image.Point{}, draw.Src)
+
+	var data bytes.Buffer
+	if err := png.Encode(&data, wide); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if err := yt.SetPlaylistImage(playlistId, data.Bytes()); err != nil {
+			t.Fatalf("SetPlaylistImage: %v", err)
+		}
+	}
+
+	cover, err := jpeg.Decode(bytes.NewReader(server.PlaylistImage(playlistId)))
+	if err != nil {
+		t.Fatalf("expected a JPEG: %v", err)
+	}
+	if size := cover.Bounds().Size(); size.X != coverWidth || size.Y != coverHeight {
+		t.Fatalf("expected [%dx%d], got %v", coverWidth, coverHeight, size)
+	}
+	for _, x := range []int{0, coverWidth - 1} {
+		if r, _, b, _ := cover.At(x, coverHeight/2).RGBA(); r < b {
+			t.Errorf("expected the edge at [%d] to be cropped to red", x)
+		}
+	}
+
+	if server.Calls("playlistImages.insert") != 1 || server.Calls("playlistImages.update") != 1 {
+		t.Fatal("expected the second image to replace the first")
+	}
+	if yt.Credits != 2*(playlistImagesListCost+playlistImagesInsertCost) {
+		t.Fatalf("unexpected credits [%d]", yt.Credits)
+	}
+}
+
+func TestGetPlaylistsPaginates(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	for range 120 {
+		server.AddPlaylist("Playlist")
+	}
+
+	if playlists := yt.GetPlaylists(); len(playlists) != 120 {
+		t.Fatalf("expected 120 Playlists, got [%d]", len(playlists))
+	}
+	if calls := server.Calls("playlists.list"); calls != 3 {
+		t.Fatalf("expected 3 pages, got [%d]", calls)
+	}
+}
+
+func TestGetPlaylistItemsPaginates(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	playlistId := server.AddPlaylist("Long")
+	for idx := range 75 {
+		server.AddPlaylistItem(playlistId, videoId(idx), "Video")
+	}
+
+	items := yt.GetPlaylistItems(playlistId)
+	if len(items) != 75 {
+		t.Fatalf("expected 75 items, got [%d]", len(items))
+	}
+	if items[74].Snippet.ResourceId.VideoId != videoId(74) {
+		t.Fatalf("items out of order: last is [%s]", items[74].Snippet.ResourceId.VideoId)
+	}
+	if yt.Credits != 2 {
+		t.Fatalf("expected 2 credits, got [%d]", yt.Credits)
+	}
+}
+
+func TestAddToPlaylistSkipsExisting(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	playlistId := server.AddPlaylist("Mix")
+	server.AddPlaylistItem(playlistId, videoId(1), "Existing")
+
+	results, err := yt.AddToPlaylist(playlistId, videoId(1), videoId(2), videoId(3), videoId(2))
+	if err != nil {
+		t.Fatalf("AddToPlaylist: %v", err)
+	}
+
+	duplicates := []bool{true, false, false, true}
+	for idx, result := range results {
+		if result.Err != nil || result.Duplicate != duplicates[idx] {
+			t.Errorf("result [%d]: %+v", idx, result)
+		}
+	}
+
+	want := []string{videoId(1), videoId(2), videoId(3)}
+	if got := server.PlaylistVideoIds(playlistId); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if yt.Credits != 101 {
+		t.Fatalf("expected 101 credits, got [%d]", yt.Credits)
+	}
+}
+
+func TestAddToPlaylistRetriesTransientErrors(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	playlistId := server.AddPlaylist("Mix")
+	server.Fail("playlistItems.insert", http.StatusServiceUnavailable, "backendError", 2)
+	server.Fail("playlistItems.insert", http.StatusTooManyRequests, "rateLimitExceeded", 1)
+
+	results, err := yt.AddToPlaylist(playlistId, videoId(1))
+	if err != nil || results[0].Err != nil {
+		t.Fatalf("expected success after retries, got [%v] [%v]", err, results[0].Err)
+	}
+	if calls := server.Calls("playlistItems.insert"); calls != 4 {
+		t.Fatalf("expected 4 attempts, got [%d]", calls)
+	}
+}
+
+func TestAddToPlaylistDoesNotRepeatAppliedInserts(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	playlistId := server.AddPlaylist("Mix")
+	server.FailAfterApplying("playlistItems.insert", http.StatusServiceUnavailable, "backendError", 1)
+
+	results, err := yt.AddToPlaylist(playlistId, videoId(1))
+	if err != nil || results[0].Err != nil {
+		t.Fatalf("expected the applied insert to succeed, got [%v] [%v]", err, results[0].Err)
+	}
+	if got := server.PlaylistVideoIds(playlistId); !slices.Equal(got, []string{videoId(1)}) {
+		t.Fatalf("expected one copy of the video, got %v", got)
+	}
+	if calls := server.Calls("playlistItems.insert"); calls != 1 {
+		t.Fatalf("expected 1 attempt, got [%d]", calls)
+	}
+}
+
+func TestCreatePlaylistDoesNotRepeatAppliedInsert(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	server.FailAfterApplying("playlists.insert", http.StatusServiceUnavailable, "backendError", 1)
+
+	playlistId, isNew := yt.CreatePlaylist(PlaylistSource{SpotifyId: "spotify1", Name: "Road Trip"}, "")
+	playlists := server.Playlists()
+	if !isNew || len(playlists) != 1 || playlists[0].Id != playlistId {
+		t.Fatalf("expected one new Playlist [%s], got %+v", playlistId, playlists)
+	}
+}
+
+func TestAddToPlaylistSkipsUnavailableVideos(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	playlistId := server.AddPlaylist("Mix")
+	server.MakeUnavailable(videoId(2))
+
+	results, err := yt.AddToPlaylist(playlistId, videoId(1), videoId(2), videoId(3))
+	if err != nil {
+		t.Fatalf("AddToPlaylist: %v", err)
+	}
+	if results[1].Err == nil {
+		t.Fatal("expected the unavailable video to fail")
+	}
+
+	want := []string{videoId(1), videoId(3)}
+	if got := server.PlaylistVideoIds(playlistId); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if calls := server.Calls("playlistItems.insert"); calls != 3 {
+		t.Fatalf("expected no retries, got [%d] inserts", calls)
+	}
+}
+
+func TestAddToPlaylistStopsWhenQuotaExceeded(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	playlistId := server.AddPlaylist("Mix")
+	server.QuotaLimit = 51
+
+	results, err := yt.AddToPlaylist(playlistId, videoId(1), videoId(2), videoId(3))
+	if err == nil {
+		t.Fatal("expected quotaExceeded")
+	}
+	if results[0].Err != nil || results[1].Err == nil || results[2].Err == nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if calls := server.Calls("playlistItems.insert"); calls != 2 {
+		t.Fatalf("expected insertion to stop, got [%d] inserts", calls)
+	}
+}
+
+func TestAddToPlaylistInBatches(t *testing.T) {
+	yt, server := newTestYouTube(t)
+	yt.insertMethod = config.InsertBatch
+
+	playlistId := server.AddPlaylist("Mix")
+	server.MakeUnavailable(videoId(3))
+
+	var videoIds []string
+	for idx := range 60 {
+		videoIds = append(videoIds, videoId(idx))
+	}
+
+	results, err := yt.AddToPlaylist(playlistId, videoIds...)
+	if err != nil {
+		t.Fatalf("AddToPlaylist: %v", err)
+	}
+	if results[3].Err == nil {
+		t.Fatal("expected the unavailable video to fail")
+	}
+
+	if got := server.PlaylistVideoIds(playlistId); len(got) != 59 || got[3] != videoId(4) {
+		t.Fatalf("expected 59 videos in order, got %v", got)
+	}
+	if calls := server.Calls("batch"); calls != 2 {
+		t.Fatalf("expected 2 batch requests, got [%d]", calls)
+	}
+	// One list, then 59 inserts
+	if yt.Credits != 1+59*50 {
+		t.Fatalf("expected [%d] credits, got [%d]", 1+59*50, yt.Credits)
+	}
+}
+
+func TestAddToPlaylistRetriesFailedBatchParts(t *testing.T) {
+	yt, server := newTestYouTube(t)
+	yt.insertMethod = config.InsertBatch
+
+	playlistId := server.AddPlaylist("Mix")
+	server.Fail("playlistItems.insert", http.StatusServiceUnavailable, "backendError", 1)
+	server.Fail("playlistItems.insert", http.StatusTooManyRequests, "rateLimitExceeded", 1)
+
+	var videoIds []string
+	for idx := range 60 {
+		videoIds = append(videoIds, videoId(idx))
+	}
+
+	results, err := yt.AddToPlaylist(playlistId, videoIds...)
+	if err != nil {
+		t.Fatalf("AddToPlaylist: %v", err)
+	}
+	for idx, result := range results {
+		if result.Err != nil {
+			t.Errorf("result [%d]: %+v", idx, result)
+		}
+	}
+
+	if got := server.PlaylistVideoIds(playlistId); len(got) != 60 {
+		t.Fatalf("expected 60 videos, got [%d]", len(got))
+	}
+	// The first chunk is sent, then its two failed parts, then the second chunk
+	if calls := server.Calls("batch"); calls != 3 {
+		t.Fatalf("expected 3 batch requests, got [%d]", calls)
+	}
+	if calls := server.Calls("playlistItems.insert"); calls != 62 {
+		t.Fatalf("expected only the failed parts to be sent again, got [%d] inserts", calls)
+	}
+}
+
+func TestAddToPlaylistResendsOnlyMissingBatchInserts(t *testing.T) {
+	yt, server := newTestYouTube(t)
+	yt.insertMethod = config.InsertBatch
+
+	playlistId := server.AddPlaylist("Mix")
+	server.FailAfterApplying("batch", http.StatusServiceUnavailable, "backendError", 1)
+
+	results, err := yt.AddToPlaylist(playlistId, videoId(1), videoId(2), videoId(3))
+	if err != nil {
+		t.Fatalf("AddToPlaylist: %v", err)
+	}
+	for idx, result := range results {
+		if result.Err != nil {
+			t.Errorf("result [%d]: %+v", idx, result)
+		}
+	}
+
+	want := []string{videoId(1), videoId(2), videoId(3)}
+	if got := server.PlaylistVideoIds(playlistId); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if calls := server.Calls("batch"); calls != 1 {
+		t.Fatalf("expected the applied batch not to be sent again, got [%d] requests", calls)
+	}
+}
+
+func TestAddToPlaylistThroughInnerTube(t *testing.T) {
+	yt, server := newTestYouTube(t)
+	yt.insertMethod = config.InsertInnerTube
+	yt.SetInnerTubeEditor(server.InnerTube())
+
+	playlistId := server.AddPlaylist("Mix")
+
+	results, err := yt.AddToPlaylist(playlistId, videoId(1), videoId(2), videoId(3))
+	if err != nil {
+		t.Fatalf("AddToPlaylist: %v", err)
+	}
+	for idx, result := range results {
+		if result.Err != nil {
+			t.Errorf("result [%d]: %+v", idx, result)
+		}
+	}
+
+	want := []string{videoId(1), videoId(2), videoId(3)}
+	if got := server.PlaylistVideoIds(playlistId); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if calls := server.Calls("playlistItems.insert"); calls != 0 {
+		t.Fatalf("expected no Data API inserts, got [%d]", calls)
+	}
+	// Only the list of existing items is charged
+	if yt.Credits != 1 {
+		t.Fatalf("expected 1 credit, got [%d]", yt.Credits)
+	}
+}
+
+func TestAddToPlaylistFallsBackFromInnerTube(t *testing.T) {
+	yt, server := newTestYouTube(t)
+	yt.insertMethod = config.InsertInnerTube
+	yt.SetInnerTubeEditor(server.InnerTube())
+
+	playlistId := server.AddPlaylist("Mix")
+	server.MakeUnavailable(videoId(2))
+
+	results, err := yt.AddToPlaylist(playlistId, videoId(1), videoId(2), videoId(3))
+	if err != nil {
+		t.Fatalf("AddToPlaylist: %v", err)
+	}
+	if results[0].Err != nil || results[1].Err == nil || results[2].Err != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	want := []string{videoId(1), videoId(3)}
+	if got := server.PlaylistVideoIds(playlistId); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if calls := server.Calls("innertube.editPlaylist"); calls != 1 {
+		t.Fatalf("expected 1 InnerTube request, got [%d]", calls)
+	}
+	if calls := server.Calls("playlistItems.insert"); calls != 3 {
+		t.Fatalf("expected the Data API to insert each video, got [%d]", calls)
+	}
+}
+
+func TestAddToPlaylistFallsBackFromInnerTubeWithoutRepeating(t *testing.T) {
+	yt, server := newTestYouTube(t)
+	yt.insertMethod = config.InsertInnerTube
+	yt.SetInnerTubeEditor(server.InnerTube())
+
+	playlistId := server.AddPlaylist("Mix")
+	server.FailAfterApplying("innertube.editPlaylist", http.StatusServiceUnavailable, "backendError", 1)
+
+	results, err := yt.AddToPlaylist(playlistId, videoId(1), videoId(2))
+	if err != nil {
+		t.Fatalf("AddToPlaylist: %v", err)
+	}
+	for idx, result := range results {
+		if result.Err != nil {
+			t.Errorf("result [%d]: %+v", idx, result)
+		}
+	}
+
+	want := []string{videoId(1), videoId(2)}
+	if got := server.PlaylistVideoIds(playlistId); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if calls := server.Calls("playlistItems.insert"); calls != 0 {
+		t.Fatalf("expected no Data API inserts, got [%d]", calls)
+	}
+}
+
+func videoId(idx int) string {
+	return fmt.Sprintf("video%06d", idx)
+}
+
+func TestRemoveDuplicates(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	playlistId := server.AddPlaylist("Old Mix")
+	server.AddPlaylistItem(playlistId, videoId(1), "Band - Alpha Wave")
+	server.AddPlaylistItem(playlistId, videoId(2), "Band - Beta Blues")
+	server.AddPlaylistItem(playlistId, videoId(1), "Band - Alpha Wave")
+	server.AddPlaylistItem(playlistId, videoId(3), "Band - Alpha Wave (Official Video)")
+	server.AddPlaylistItem(playlistId, videoId(4), "Deleted video")
+	server.AddPlaylistItem(playlistId, videoId(5), "Deleted video")
+	server.AddPlaylistItem(playlistId, videoId(2), "Band - Beta Blues")
+
+	groups := yt.FindDuplicates(playlistId, config.MinDedupeThreshold)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %+v", groups)
+	}
+	if groups[0].Keep.Snippet.Position != 0 || len(groups[0].Remove) != 2 || groups[0].Exact {
+		t.Errorf("unexpected first group: %+v", groups[0])
+	}
+	if groups[1].Keep.Snippet.Position != 1 || len(groups[1].Remove) != 1 || !groups[1].Exact {
+		t.Errorf("unexpected second group: %+v", groups[1])
+	}
+
+	var plan strings.Builder
+	if err := WriteDuplicatePlan(&plan, groups); err != nil {
+		t.Fatalf("WriteDuplicatePlan: %v", err)
+	}
+	if !strings.Contains(plan.String(), "3 duplicates to remove. Credits: 150") {
+		t.Fatalf("unexpected plan:\n%s", plan.String())
+	}
+
+	credits := yt.Credits
+	removed, err := yt.RemoveDuplicates(groups)
+	if err != nil || removed != 3 {
+		t.Fatalf("expected 3 removed, got [%d] %v", removed, err)
+	}
+
+	want := []string{videoId(1), videoId(2), videoId(4), videoId(5)}
+	if got := server.PlaylistVideoIds(playlistId); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if yt.Credits-credits != 150 || server.Calls("playlistItems.delete") != 3 {
+		t.Fatalf("expected 3 deletes for 150 credits, got [%d] for [%d]", server.Calls("playlistItems.delete"), yt.Credits-credits)
+	}
+}
+
+func TestFindDuplicatesKeepsDifferentSongs(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	playlistId := server.AddPlaylist("Old Mix")
+	server.AddPlaylistItem(playlistId, videoId(1), "Metallica - One")
+	server.AddPlaylistItem(playlistId, videoId(2), "Metallica - Fuel")
+	server.AddPlaylistItem(playlistId, videoId(3), "Taylor Swift - Style")
+	server.AddPlaylistItem(playlistId, videoId(4), "Taylor Swift - Stay")
+	server.AddPlaylistItem(playlistId, videoId(5), "Band - Alpha Wave")
+	server.AddPlaylistItem(playlistId, videoId(6), "Band - Alpha Wave (Official Video)")
+	server.AddPlaylistItem(playlistId, videoId(3), "Taylor Swift - Style")
+
+	// Titles by the same artist are not the same song
+	groups := yt.FindDuplicates(playlistId, config.MinDedupeThreshold)
+	if len(groups) != 2 || !groups[0].Exact || groups[0].Keep.Snippet.ResourceId.VideoId != videoId(3) || groups[1].Exact {
+		t.Fatalf("expected only the repeated video and the official video to be grouped, got %+v", groups)
+	}
+
+	// Without a threshold only the same video is grouped
+	groups = yt.FindDuplicates(playlistId, 0)
+	if len(groups) != 1 || !groups[0].Exact || len(groups[0].Remove) != 1 {
+		t.Fatalf("expected only the repeated video to be grouped, got %+v", groups)
+	}
+}
+
+func TestGetTrackRejectsWrongLength(t *testing.T) {
+	yt, server := newTestYouTube(t)
+
+	server.AddVideo("loopvideo01", "Artist Song", "UCloop")
+	server.AddVideo("shortvideo1", "Artist Song #shorts", "UCshorts")
+	server.AddVideo("rightlength", "Artist - Song (Official Video)", "UCartist")
+	server.SetDuration("loopvideo01", time.Hour)
+	server.SetDuration("shortvideo1", 30*time.Second)
+	server.SetDuration("rightlength", 3*time.Minute+40*time.Second)
+
+	track := Track{Artist: "Artist", Name: "Song", Duration: 215000}
+	match, err := yt.GetTrack(track, 5)
+	if err != nil {
+		t.Fatalf("GetTrack: %v", err)
+	}
+	if best := match.Best(); best == nil || best.VideoId != "rightlength" || best.Length != "3:40" {
+		t.Fatalf("expected the video of the right length, got %+v", match.Candidates)
+	}
+
+	if server.Calls("videos.list") != 1 || yt.Credits != 101 {
+		t.Errorf("expected one videos.list call and 101 credits, got [%d] and [%d]", server.Calls("videos.list"), yt.Credits)
+	}
+}